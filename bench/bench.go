@@ -0,0 +1,97 @@
+// Package bench provides the parsing of the output of the Go benchmarks,
+// and the comparison of the results between two runs.
+package bench
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Key identifies a measurement of a benchmark.
+type Key struct {
+	// Package is the import path of the package of the benchmark, from the `pkg:` line of the output (e.g. `github.com/VassilisPallas/gvs/version`).
+	Package string
+
+	// Name is the name of the benchmark, without the `Benchmark` prefix (e.g. `Resolve-8`).
+	Name string
+
+	// Unit is the unit of the measurement (e.g. `ns/op`).
+	Unit string
+}
+
+// Results contains the measurements of the benchmarks, in the order they are found.
+type Results struct {
+	// keys contains the keys of the measurements, in the order they are found.
+	keys []Key
+
+	// values contains the values of each measurement from every run.
+	values map[Key][]float64
+}
+
+// NewResults returns new empty Results.
+func NewResults() *Results {
+	return &Results{values: map[Key][]float64{}}
+}
+
+// Keys returns the keys of the measurements, in the order they are found.
+func (r *Results) Keys() []Key {
+	return r.keys
+}
+
+// Values returns the values of the given measurement from every run.
+func (r *Results) Values(key Key) []float64 {
+	return r.values[key]
+}
+
+// add appends the value of a run to the given measurement.
+func (r *Results) add(key Key, value float64) {
+	if _, ok := r.values[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+
+	r.values[key] = append(r.values[key], value)
+}
+
+// Parse reads the output of `go test -bench` and adds the measurements of the benchmarks to the results.
+//
+// A benchmark line has the format `BenchmarkName-8   1000   1234 ns/op   16 B/op   1 allocs/op`, where the
+// measurements come in value-unit pairs after the count of the iterations. Any other line is ignored.
+//
+// If the output can't be read, Parse returns back an error.
+func (r *Results) Parse(output io.Reader) error {
+	pkg := ""
+
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if after, ok := strings.CutPrefix(line, "pkg:"); ok {
+			pkg = strings.TrimSpace(after)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") || len(fields[0]) == len("Benchmark") {
+			continue
+		}
+
+		if _, err := strconv.ParseUint(fields[1], 10, 64); err != nil {
+			continue
+		}
+
+		name := strings.TrimPrefix(fields[0], "Benchmark")
+
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				break
+			}
+
+			r.add(Key{Package: pkg, Name: name, Unit: fields[i+1]}, value)
+		}
+	}
+
+	return scanner.Err()
+}
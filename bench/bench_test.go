@@ -0,0 +1,66 @@
+package bench_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/bench"
+	"github.com/google/go-cmp/cmp"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("read error")
+}
+
+func TestParse(t *testing.T) {
+	output := `goos: linux
+goarch: amd64
+pkg: example.com/first
+BenchmarkParse-8   	 1000	      1234 ns/op	      16 B/op	       1 allocs/op
+BenchmarkParse-8   	 1000	      1250 ns/op	      16 B/op	       1 allocs/op
+BenchmarkBroken-8  	 --- FAIL: BenchmarkBroken-8
+Benchmark
+PASS
+ok  	example.com/first	1.234s
+pkg: example.com/second
+BenchmarkParse/small-8   	 2000	       500.5 ns/op
+`
+
+	results := bench.NewResults()
+	if err := results.Parse(strings.NewReader(output)); err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	expectedKeys := []bench.Key{
+		{Package: "example.com/first", Name: "Parse-8", Unit: "ns/op"},
+		{Package: "example.com/first", Name: "Parse-8", Unit: "B/op"},
+		{Package: "example.com/first", Name: "Parse-8", Unit: "allocs/op"},
+		{Package: "example.com/second", Name: "Parse/small-8", Unit: "ns/op"},
+	}
+
+	if !cmp.Equal(results.Keys(), expectedKeys) {
+		t.Errorf("Wrong keys received, got=%s", cmp.Diff(results.Keys(), expectedKeys))
+	}
+
+	expectedValues := [][]float64{{1234, 1250}, {16, 16}, {1, 1}, {500.5}}
+	for i, key := range expectedKeys {
+		if !cmp.Equal(results.Values(key), expectedValues[i]) {
+			t.Errorf("Wrong values received for %v, got=%s", key, cmp.Diff(results.Values(key), expectedValues[i]))
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	expectedError := errors.New("read error")
+
+	results := bench.NewResults()
+	err := results.Parse(failingReader{})
+
+	if err == nil || err.Error() != expectedError.Error() {
+		t.Errorf("error should be %q, instead got %v", expectedError.Error(), err)
+	}
+}
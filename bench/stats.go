@@ -0,0 +1,167 @@
+package bench
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultAlpha is the significance level that is used to decide if the difference between two runs is significant.
+const DefaultAlpha = 0.05
+
+// Comparison contains the comparison of a measurement between the old and the new runs.
+type Comparison struct {
+	Key
+
+	// OldMean is the mean of the values of the old runs.
+	OldMean float64
+
+	// NewMean is the mean of the values of the new runs.
+	NewMean float64
+
+	// OldVariation is the maximum distance of the values of the old runs from their mean, as a percentage of the mean.
+	OldVariation float64
+
+	// NewVariation is the maximum distance of the values of the new runs from their mean, as a percentage of the mean.
+	NewVariation float64
+
+	// OldCount is the count of the old runs.
+	OldCount int
+
+	// NewCount is the count of the new runs.
+	NewCount int
+
+	// Delta is the change from the old mean to the new mean, as a percentage of the old mean.
+	Delta float64
+
+	// P is the p-value of the Mann-Whitney U test between the old and the new runs.
+	P float64
+
+	// Significant is true if P is lower than the significance level, which means that the change is not caused from noise.
+	Significant bool
+}
+
+// Compare returns the comparison of every measurement that exists in both the old and the new results,
+// in the order they are found in the old results. alpha is the significance level of the test (see DefaultAlpha).
+func Compare(old *Results, new *Results, alpha float64) []Comparison {
+	comparisons := []Comparison{}
+
+	for _, key := range old.Keys() {
+		oldValues, newValues := old.Values(key), new.Values(key)
+		if len(newValues) == 0 {
+			continue
+		}
+
+		comparison := Comparison{
+			Key:          key,
+			OldMean:      mean(oldValues),
+			NewMean:      mean(newValues),
+			OldVariation: variation(oldValues),
+			NewVariation: variation(newValues),
+			OldCount:     len(oldValues),
+			NewCount:     len(newValues),
+			P:            MannWhitneyU(oldValues, newValues),
+		}
+
+		if comparison.OldMean != 0 {
+			comparison.Delta = (comparison.NewMean - comparison.OldMean) / comparison.OldMean * 100
+		}
+
+		comparison.Significant = comparison.P < alpha
+		comparisons = append(comparisons, comparison)
+	}
+
+	return comparisons
+}
+
+// mean returns the arithmetic mean of the given values.
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+// variation returns the maximum distance of the given values from their mean, as a percentage of the mean.
+func variation(values []float64) float64 {
+	m := mean(values)
+	if m == 0 {
+		return 0
+	}
+
+	distance := 0.0
+	for _, value := range values {
+		distance = math.Max(distance, math.Abs(value-m))
+	}
+
+	return distance / m * 100
+}
+
+// MannWhitneyU returns the two-sided p-value of the Mann-Whitney U test for the given samples, which tests
+// if the values of one sample tend to be larger than the values of the other, without assuming they are normally distributed.
+//
+// The p-value is calculated with the normal approximation, with a correction for the ties and for the continuity.
+// If any of the samples is empty or all the values are equal, MannWhitneyU returns 1.
+func MannWhitneyU(x []float64, y []float64) float64 {
+	n1, n2 := float64(len(x)), float64(len(y))
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type sample struct {
+		value float64
+		first bool
+	}
+
+	samples := make([]sample, 0, len(x)+len(y))
+	for _, value := range x {
+		samples = append(samples, sample{value: value, first: true})
+	}
+	for _, value := range y {
+		samples = append(samples, sample{value: value})
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].value < samples[j].value
+	})
+
+	// the tied values get the average of their ranks.
+	rankSum, ties := 0.0, 0.0
+	for i := 0; i < len(samples); {
+		j := i
+		for j < len(samples) && samples[j].value == samples[i].value {
+			j++
+		}
+
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if samples[k].first {
+				rankSum += rank
+			}
+		}
+
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+
+	n := n1 + n2
+	u := rankSum - n1*(n1+1)/2
+	mu := n1 * n2 / 2
+	sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - ties/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+
+	z := (math.Abs(u-mu) - 0.5) / sigma
+	if z < 0 {
+		return 1
+	}
+
+	return math.Erfc(z / math.Sqrt2)
+}
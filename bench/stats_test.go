@@ -0,0 +1,109 @@
+package bench_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/bench"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	testCases := []struct {
+		testTitle string
+		x         []float64
+		y         []float64
+		expectedP float64
+	}{
+		{
+			testTitle: "should return a low p-value when the samples don't overlap",
+			x:         []float64{1, 2, 3, 4, 5},
+			y:         []float64{6, 7, 8, 9, 10},
+			expectedP: 0.0122,
+		},
+		{
+			testTitle: "should return a high p-value when the samples overlap",
+			x:         []float64{1, 3, 5, 7, 9},
+			y:         []float64{2, 4, 6, 8, 10},
+			expectedP: 0.6761,
+		},
+		{
+			testTitle: "should correct the p-value for the ties",
+			x:         []float64{1, 1, 2, 2},
+			y:         []float64{3, 3, 4, 4},
+			expectedP: 0.0265,
+		},
+		{
+			testTitle: "should return 1 when all the values are equal",
+			x:         []float64{5, 5, 5},
+			y:         []float64{5, 5, 5},
+			expectedP: 1,
+		},
+		{
+			testTitle: "should return 1 when a sample is empty",
+			x:         []float64{1, 2, 3},
+			y:         []float64{},
+			expectedP: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			p := bench.MannWhitneyU(tc.x, tc.y)
+
+			if math.Abs(p-tc.expectedP) > 0.0001 {
+				t.Errorf("p-value should be %.4f, instead got %.4f", tc.expectedP, p)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	old := bench.NewResults()
+	new := bench.NewResults()
+
+	oldOutput := `BenchmarkParse-8 1000 100 ns/op 16 B/op
+BenchmarkParse-8 1000 110 ns/op 16 B/op
+BenchmarkParse-8 1000 90 ns/op 16 B/op
+BenchmarkParse-8 1000 100 ns/op 16 B/op
+BenchmarkParse-8 1000 100 ns/op 16 B/op
+BenchmarkRemoved-8 1000 10 ns/op
+`
+	newOutput := `BenchmarkParse-8 1000 80 ns/op 16 B/op
+BenchmarkParse-8 1000 80 ns/op 16 B/op
+BenchmarkParse-8 1000 80 ns/op 16 B/op
+BenchmarkParse-8 1000 80 ns/op 16 B/op
+BenchmarkParse-8 1000 80 ns/op 16 B/op
+`
+
+	if err := old.Parse(strings.NewReader(oldOutput)); err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	if err := new.Parse(strings.NewReader(newOutput)); err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	comparisons := bench.Compare(old, new, bench.DefaultAlpha)
+
+	if len(comparisons) != 2 {
+		t.Errorf("comparisons should be 2, instead got %d", len(comparisons))
+		return
+	}
+
+	time, bytes := comparisons[0], comparisons[1]
+
+	if time.Unit != "ns/op" || time.OldMean != 100 || time.NewMean != 80 || time.Delta != -20 || !time.Significant {
+		t.Errorf("wrong comparison for ns/op, got %+v", time)
+	}
+
+	if time.OldVariation != 10 || time.NewVariation != 0 || time.OldCount != 5 || time.NewCount != 5 {
+		t.Errorf("wrong variation or count for ns/op, got %+v", time)
+	}
+
+	if bytes.Unit != "B/op" || bytes.Delta != 0 || bytes.Significant || bytes.P != 1 {
+		t.Errorf("wrong comparison for B/op, got %+v", bytes)
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/VassilisPallas/gvs/version"
+)
+
+// apiDiff is the JSON output of APIDiff.
+type apiDiff struct {
+	// Old is the old version (e.g. `1.20.14`).
+	Old string `json:"old"`
+
+	// New is the new version (e.g. `1.22.1`).
+	New string `json:"new"`
+
+	// Packages contains the features that are added on each package.
+	Packages []version.PackageAPI `json:"packages"`
+}
+
+// APIDiff prints the features of the standard library API that are added from the old to the new version, grouped by package,
+// in the given format (`text` or `json`). Both versions must be installed, since the API is read from their GOROOT,
+// so it works without a network connection.
+//
+// If the format is not supported, any of the versions can't be resolved from the installed ones,
+// or their API can't be read, APIDiff returns back an error.
+func (cli CLI) APIDiff(oldVersion string, newVersion string, format string) error {
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	versions := []*version.ExtendedVersion{}
+	for _, goVersion := range []string{oldVersion, newVersion} {
+		selectedVersion, err := cli.findVersion(goVersion)
+		if err != nil {
+			return fmt.Errorf("%s can't be found in the installed versions: %w", goVersion, err)
+		}
+
+		versions = append(versions, selectedVersion)
+	}
+
+	packages, err := cli.versioner.DiffAPI(versions[0], versions[1])
+	if err != nil {
+		return err
+	}
+
+	oldName, newName := strings.TrimPrefix(versions[0].Version, "go"), strings.TrimPrefix(versions[1].Version, "go")
+
+	if format == JSONFormat {
+		output, err := json.MarshalIndent(apiDiff{Old: oldName, New: newName, Packages: packages}, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		cli.log.PrintMessage("%s", output)
+		return nil
+	}
+
+	if len(packages) == 0 {
+		cli.log.PrintMessage("no APIs are added from %s to %s", oldName, newName)
+		return nil
+	}
+
+	var output strings.Builder
+	for i, pkg := range packages {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+
+		fmt.Fprintf(&output, "%s\n", pkg.Package)
+		for _, feature := range pkg.Features {
+			fmt.Fprintf(&output, "  %s\n", feature)
+		}
+	}
+
+	cli.log.PrintMessage("%s", strings.TrimSuffix(output.String(), "\n"))
+	return nil
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/VassilisPallas/gvs/bench"
+	"github.com/VassilisPallas/gvs/version"
+)
+
+// runBenchmarks runs `go test` with the given version and arguments, and adds the measurements of the benchmarks to the results.
+// The tests are skipped and all the benchmarks run, unless the arguments override the `-run` and `-bench` flags.
+//
+// If the command fails, the output of the command is printed and runBenchmarks returns back an error.
+func (cli CLI) runBenchmarks(ev *version.ExtendedVersion, args []string, results *bench.Results) error {
+	name := strings.TrimPrefix(ev.Version, "go")
+
+	// the flags of the arguments are passed after the default ones, so they take precedence.
+	testArgs := append([]string{"test", "-run=^$", "-bench=.", "-count=1"}, args...)
+
+	var output bytes.Buffer
+	cmd := cli.commander.NewCommand(cli.getGoroot(ev), "go", testArgs)
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", version.VersionEnvName, name))
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	if err := cmd.Run(); err != nil {
+		cli.log.PrintError("%s", output.String())
+		// the error is not wrapped, since the exit code of `go test` is not the exit code of gvs (see gocmd.GetExitCode).
+		return fmt.Errorf("the benchmarks failed with %s: %s", name, err.Error())
+	}
+
+	return results.Parse(&output)
+}
+
+// Bench runs the benchmarks with the old and the new version for the given count of iterations, and prints
+// the change of every measurement, together with the p-value of the Mann-Whitney U test, in the same style as benchstat.
+// The arguments are passed to `go test` (e.g. `-bench=Resolve ./version`).
+//
+// The versions that are not installed are downloaded first, without switching to them. Every iteration runs
+// the benchmarks once with each version, one after the other, so any noise of the machine affects both versions.
+//
+// If any of the versions can't be resolved or downloaded, or if the benchmarks fail, Bench returns back an error.
+func (cli CLI) Bench(oldVersion string, newVersion string, args []string, count int) error {
+	versions := []*version.ExtendedVersion{}
+
+	for _, goVersion := range []string{oldVersion, newVersion} {
+		selectedVersion, err := cli.findVersion(goVersion)
+		if err != nil {
+			return err
+		}
+
+		versions = append(versions, selectedVersion)
+	}
+
+	if err := cli.downloadIfNeeded(versions...); err != nil {
+		return err
+	}
+
+	if count < 1 {
+		count = 1
+	}
+
+	oldResults, newResults := bench.NewResults(), bench.NewResults()
+
+	for i := 1; i <= count; i++ {
+		cli.log.PrintMessage("running the benchmarks (%d/%d)", i, count)
+
+		if err := cli.runBenchmarks(versions[0], args, oldResults); err != nil {
+			return err
+		}
+
+		if err := cli.runBenchmarks(versions[1], args, newResults); err != nil {
+			return err
+		}
+	}
+
+	comparisons := bench.Compare(oldResults, newResults, bench.DefaultAlpha)
+	if len(comparisons) == 0 {
+		return fmt.Errorf("no benchmarks found, check the arguments that are passed to 'go test'")
+	}
+
+	oldName, newName := strings.TrimPrefix(versions[0].Version, "go"), strings.TrimPrefix(versions[1].Version, "go")
+
+	var table strings.Builder
+	writer := tabwriter.NewWriter(&table, 0, 0, 3, ' ', 0)
+
+	pkg := ""
+	for i, comparison := range comparisons {
+		if i == 0 || comparison.Package != pkg {
+			pkg = comparison.Package
+			if i > 0 {
+				fmt.Fprintln(writer)
+			}
+			if pkg != "" {
+				fmt.Fprintf(writer, "pkg: %s\n", pkg)
+			}
+			fmt.Fprintf(writer, "NAME\t%s\t%s\tDELTA\n", oldName, newName)
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", comparison.Name,
+			formatMeasurement(comparison.OldMean, comparison.OldVariation, comparison.Unit),
+			formatMeasurement(comparison.NewMean, comparison.NewVariation, comparison.Unit),
+			formatDelta(comparison))
+	}
+	writer.Flush()
+
+	cli.log.PrintMessage("\n%s", table.String())
+
+	return nil
+}
+
+// formatMeasurement returns the given mean and variation in the format `1234 ns/op ± 2%`.
+func formatMeasurement(mean float64, variation float64, unit string) string {
+	return fmt.Sprintf("%.4g %s ± %.0f%%", mean, unit, variation)
+}
+
+// formatDelta returns the delta of the given comparison together with the p-value and the count of the runs,
+// or `~` instead of the delta if the change is not significant.
+func formatDelta(comparison bench.Comparison) string {
+	delta := "~"
+	if comparison.Significant {
+		delta = fmt.Sprintf("%+.2f%%", comparison.Delta)
+	}
+
+	return fmt.Sprintf("%s (p=%.3f n=%d+%d)", delta, comparison.P, comparison.OldCount, comparison.NewCount)
+}
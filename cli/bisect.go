@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/VassilisPallas/gvs/version"
+)
+
+// bisectSkipExitCode is the exit code the command can use to skip the version it runs with,
+// the same as `git bisect run`.
+const bisectSkipExitCode = 125
+
+// runBisectVersion runs the given command with the given version, and returns back if the version is good, bad or skipped.
+//
+// The version is downloaded first, if it's not already downloaded, and it's skipped if it can't be downloaded.
+// If the command fails, it runs again up to retries times, and the version is good if any of the runs passes.
+//
+// If the command can't run at all (e.g. it can't be found), runBisectVersion returns back an error.
+func (cli CLI) runBisectVersion(ev *version.ExtendedVersion, command []string, retries int) (version.BisectStatus, error) {
+	name := strings.TrimPrefix(ev.Version, "go")
+
+	if err := cli.downloadIfNeeded(ev); err != nil {
+		cli.log.PrintError("%s can't be downloaded: %s", name, err.Error())
+		return version.BisectSkip, nil
+	}
+
+	goroot := cli.getGoroot(ev)
+
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			cli.log.PrintMessage("retrying %s (%d/%d)", name, attempt, retries)
+		}
+
+		cmd := cli.commander.NewCommand(goroot, command[0], command[1:])
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", version.VersionEnvName, name))
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		err := cmd.Run()
+		if err == nil {
+			return version.BisectGood, nil
+		}
+
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return version.BisectSkip, err
+		}
+
+		if exitErr.ExitCode() == bisectSkipExitCode {
+			return version.BisectSkip, nil
+		}
+	}
+
+	return version.BisectBad, nil
+}
+
+// Bisect finds the first release between the good and the bad version that introduced a regression,
+// with a binary search over the releases between them. The given command runs with each release that is tested,
+// without changing the version that is selected with `gvs use`, and a release is bad if the command fails.
+//
+// The releases that are not downloaded are downloaded when they are tested. A release is skipped if it can't be downloaded,
+// if the command exits with the exit code 125, or if it's one of the given skip versions. Because of flaky runs,
+// a failed command runs again up to retries times before the release is marked as bad.
+//
+// If any of the versions can't be resolved, or if the command can't run, Bisect returns back an error.
+func (cli CLI) Bisect(good string, bad string, skip []string, command []string, retries int) error {
+	versions, err := cli.versioner.FindBisectVersions(cli.versions, good, bad, cli.options.IncludePrerelease)
+	if err != nil {
+		return err
+	}
+
+	bisect := version.NewBisect(versions)
+
+	for _, query := range skip {
+		ev, err := cli.findVersion(query)
+		if err != nil {
+			return err
+		}
+
+		bisect.Mark(ev, version.BisectSkip)
+	}
+
+	for ev := bisect.Next(); ev != nil; ev = bisect.Next() {
+		name := strings.TrimPrefix(ev.Version, "go")
+		cli.log.PrintMessage("testing %s (remaining versions: %d)", name, bisect.Remaining())
+
+		status, err := cli.runBisectVersion(ev, command, retries)
+		if err != nil {
+			return err
+		}
+
+		cli.log.PrintMessage("%s is %s", name, status)
+		bisect.Mark(ev, status)
+	}
+
+	firstBad := bisect.FirstBad()
+	if len(firstBad) == 1 {
+		cli.log.PrintMessage("%s is the first bad version", strings.TrimPrefix(firstBad[0].Version, "go"))
+		return nil
+	}
+
+	names := make([]string, 0, len(firstBad))
+	for _, ev := range firstBad {
+		names = append(names, strings.TrimPrefix(ev.Version, "go"))
+	}
+
+	cli.log.PrintMessage("the first bad version can't be found because of the skipped versions, it's one of: %s", strings.Join(names, ", "))
+	return nil
+}
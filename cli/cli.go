@@ -3,36 +3,91 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"os"
+	"os/signal"
 	"runtime"
+	"strings"
+	"syscall"
 
 	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/pkg/gocmd"
+	"github.com/VassilisPallas/gvs/shell"
 	"github.com/VassilisPallas/gvs/version"
+	"github.com/manifoldco/promptui"
 )
 
+// Options contains the options that change how the versions are resolved.
+type Options struct {
+	// IncludePrerelease allows the betas and the release candidates to be selected when a version
+	// or a constraint expression (e.g. `>=1.21.4 <1.23`) is resolved.
+	IncludePrerelease bool
+}
+
 type CLI struct {
 	versions  []*version.ExtendedVersion
 	versioner version.Versioner
+	commander gocmd.Commander
 	log       logger.Logger
+	options   Options
 }
 
 func (cli CLI) Install(selectedVersion *version.ExtendedVersion) error {
 	cli.log.Info("selected %s version\n", selectedVersion.Version)
-	return cli.versioner.Install(selectedVersion, runtime.GOOS, runtime.GOARCH)
+
+	if selectedVersion.SupportStatus == version.Unsupported {
+		cli.log.PrintMessage("Warning: %s is not supported by the Go team anymore and doesn't get security fixes.", strings.TrimPrefix(selectedVersion.Version, "go"))
+	}
+
+	alreadyInstalled := selectedVersion.AlreadyInstalled
+	if err := cli.versioner.Install(selectedVersion, runtime.GOOS, runtime.GOARCH); err != nil {
+		return err
+	}
+
+	if !alreadyInstalled {
+		cli.autoPrune(selectedVersion)
+	}
+
+	return nil
+}
+
+func (cli CLI) findVersion(goVersion string) (*version.ExtendedVersion, error) {
+	return cli.versioner.Resolve(cli.versions, goVersion, cli.options.IncludePrerelease)
 }
 
 func (cli CLI) InstallVersion(goVersion string) error {
-	semver := &version.Semver{}
-	err := version.ParseSemver(goVersion, semver)
+	selectedVersion, err := cli.findVersion(goVersion)
 	if err != nil {
 		return err
 	}
 
-	selectedVersion := cli.versioner.FindVersionBasedOnSemverName(cli.versions, semver)
-	if selectedVersion == nil {
-		return fmt.Errorf("%s is not a valid version", semver.GetVersion())
+	return cli.Install(selectedVersion)
+}
+
+func (cli CLI) DownloadVersions(goVersions []string) error {
+	selectedVersions := []*version.ExtendedVersion{}
+	downloaded := false
+
+	for _, goVersion := range goVersions {
+		selectedVersion, err := cli.findVersion(goVersion)
+		if err != nil {
+			return err
+		}
+
+		cli.log.Info("selected %s version to download\n", selectedVersion.Version)
+		alreadyInstalled := selectedVersion.AlreadyInstalled
+		if err := cli.versioner.Download(selectedVersion, runtime.GOOS, runtime.GOARCH); err != nil {
+			return err
+		}
+
+		selectedVersions = append(selectedVersions, selectedVersion)
+		downloaded = downloaded || !alreadyInstalled
 	}
 
-	return cli.Install(selectedVersion)
+	if downloaded {
+		cli.autoPrune(selectedVersions...)
+	}
+
+	return nil
 }
 
 func (cli CLI) InstallLatestVersion() error {
@@ -46,6 +101,34 @@ func (cli CLI) InstallLatestVersion() error {
 	return cli.Install(selectedVersion)
 }
 
+func (cli CLI) SelectVersion(showAllVersions bool, supportedOnly bool) error {
+	var promptVersions []*version.ExtendedVersion
+	for _, pv := range cli.versioner.GetPromptVersions(cli.versions, showAllVersions) {
+		if !supportedOnly || pv.SupportStatus == version.Supported {
+			promptVersions = append(promptVersions, pv)
+		}
+	}
+
+	var versionNames []string
+
+	for _, pv := range promptVersions {
+		versionNames = append(versionNames, pv.GetPromptName(showAllVersions))
+	}
+
+	prompt := promptui.Select{
+		Label: "Select go version",
+		Items: versionNames,
+		Size:  10,
+	}
+
+	selectedIndex, _, err := prompt.Run()
+	if err != nil {
+		return err
+	}
+
+	return cli.Install(promptVersions[selectedIndex])
+}
+
 func (cli CLI) DeleteUnusedVersions() error {
 	deleted_count, err := cli.versioner.DeleteUnusedVersions(cli.versions)
 	if err != nil {
@@ -61,6 +144,499 @@ func (cli CLI) DeleteUnusedVersions() error {
 	return nil
 }
 
-func New(versions []*version.ExtendedVersion, versioner version.Versioner, log logger.Logger) CLI {
-	return CLI{versions: versions, versioner: versioner, log: log}
+// findInstalledVersions returns the installed versions that are described from the given query.
+// A constraint describes all the installed versions that satisfy it, and any other query the installed version it resolves to.
+//
+// If the query is not valid, or no installed version is described from it, findInstalledVersions returns back an error.
+func (cli CLI) findInstalledVersions(installedVersions []*version.ExtendedVersion, query string) ([]*version.ExtendedVersion, error) {
+	if version.IsConstraint(query) {
+		found, err := version.FilterVersions(installedVersions, version.ListFilter{ShowAll: true, Constraint: query})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(found) == 0 {
+			return nil, fmt.Errorf("no installed version satisfies %q", query)
+		}
+
+		return found, nil
+	}
+
+	// resolve against the available versions first, so an invalid version is reported
+	// as such instead of as a version that is not installed.
+	if _, err := cli.versioner.Resolve(cli.versions, query, true); err != nil {
+		return nil, err
+	}
+
+	selectedVersion, err := cli.versioner.Resolve(installedVersions, query, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not installed", query)
+	}
+
+	return []*version.ExtendedVersion{selectedVersion}, nil
+}
+
+// warnUninstall prints a warning for every alias and project file (see version.GetVersionSources) that
+// refers to the given version, since they will resolve to another version (or fail) after it's deleted.
+func (cli CLI) warnUninstall(installedVersions []*version.ExtendedVersion, sources []*version.VersionSource, ev *version.ExtendedVersion) error {
+	aliases, err := cli.versioner.GetAliasesForVersion(installedVersions, ev)
+	if err != nil {
+		return err
+	}
+
+	if len(aliases) > 0 {
+		cli.log.PrintMessage("Warning: the aliases %s point to %s and will resolve to another version (or fail) after it is deleted.", strings.Join(aliases, ", "), ev.Version)
+	}
+
+	for _, source := range sources {
+		if resolved, err := cli.versioner.Resolve(cli.versions, source.Query, cli.options.IncludePrerelease); err == nil && resolved.Version == ev.Version {
+			cli.log.PrintMessage("Warning: %s uses %s, which will be downloaded again the next time it is used.", source, ev.Version)
+		}
+	}
+
+	return nil
+}
+
+// getInstalledVersions returns back the versions that are already installed.
+func (cli CLI) getInstalledVersions() []*version.ExtendedVersion {
+	installedVersions := make([]*version.ExtendedVersion, 0, len(cli.versions))
+	for _, ev := range cli.versions {
+		if ev.AlreadyInstalled {
+			installedVersions = append(installedVersions, ev)
+		}
+	}
+
+	return installedVersions
+}
+
+// uninstallVersions deletes the given installed versions. The current version is deleted only if force is true.
+// Before a version is deleted, a warning is printed for the aliases and the project files that refer to it.
+//
+// Every version is deleted even if another one fails, and the error of each one is printed.
+// uninstallVersions returns back the number of versions that can't be deleted, or an error if the warnings can't be printed.
+func (cli CLI) uninstallVersions(selectedVersions []*version.ExtendedVersion, force bool) (int, error) {
+	installedVersions := cli.getInstalledVersions()
+
+	// the project files are only used for the warnings, so any errors from them are ignored.
+	sources, err := cli.versioner.GetVersionSources(false)
+	if err != nil {
+		cli.log.Info("the project versions can't be found: %s\n", err.Error())
+	}
+
+	failed := 0
+	for _, ev := range selectedVersions {
+		if ev.UsedVersion && !force {
+			cli.log.PrintError("%s is the current version, use --force to delete it", strings.TrimPrefix(ev.Version, "go"))
+			failed++
+			continue
+		}
+
+		if err := cli.warnUninstall(installedVersions, sources, ev); err != nil {
+			return failed, err
+		}
+
+		if err := cli.versioner.Uninstall(ev, force); err != nil {
+			cli.log.PrintError(err.Error())
+			failed++
+		}
+	}
+
+	return failed, nil
+}
+
+// Uninstall deletes the installed versions that are described from the given queries, where a constraint (e.g. `<1.21`)
+// describes all the installed versions that satisfy it. The current version is deleted only if force is true.
+// Before a version is deleted, a warning is printed for the aliases and the project files that refer to it.
+//
+// Every version is deleted even if another one fails, and the result of each one is printed.
+// If any of the queries can't be resolved, or any of the versions can't be deleted, Uninstall returns back an error.
+func (cli CLI) Uninstall(goVersions []string, force bool) error {
+	installedVersions := cli.getInstalledVersions()
+
+	failed, notFound := 0, 0
+	selectedVersions := []*version.ExtendedVersion{}
+	added := map[*version.ExtendedVersion]bool{}
+
+	for _, goVersion := range goVersions {
+		found, err := cli.findInstalledVersions(installedVersions, goVersion)
+		if err != nil {
+			cli.log.PrintError(err.Error())
+			failed++
+			notFound++
+			continue
+		}
+
+		for _, ev := range found {
+			if !added[ev] {
+				added[ev] = true
+				selectedVersions = append(selectedVersions, ev)
+			}
+		}
+	}
+
+	uninstallFailed, err := cli.uninstallVersions(selectedVersions, force)
+	if err != nil {
+		return err
+	}
+	failed += uninstallFailed
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d versions can't be uninstalled", failed, notFound+len(selectedVersions))
+	}
+
+	return nil
+}
+
+func (cli CLI) SetAlias(name string, goVersion string) error {
+	if err := cli.versioner.SetAlias(name, goVersion); err != nil {
+		return err
+	}
+
+	cli.log.PrintMessage("%s now points to %s", name, goVersion)
+	return nil
+}
+
+func (cli CLI) ListAliases() error {
+	aliases, err := cli.versioner.GetAliases()
+	if err != nil {
+		return err
+	}
+
+	if len(aliases) == 0 {
+		cli.log.PrintMessage("There are no aliases")
+		return nil
+	}
+
+	for _, alias := range aliases {
+		cli.log.PrintMessage("%s -> %s", alias.Name, alias.Version)
+	}
+
+	return nil
+}
+
+func (cli CLI) DeleteAlias(name string) error {
+	if err := cli.versioner.DeleteAlias(name); err != nil {
+		return err
+	}
+
+	cli.log.PrintMessage("%s is deleted", name)
+	return nil
+}
+
+func (cli CLI) SetLocalVersion(goVersion string) error {
+	path, err := cli.versioner.SetLocalVersion(goVersion)
+	if err != nil {
+		return err
+	}
+
+	cli.log.PrintMessage("%s is set as the version of %s", goVersion, path)
+	return nil
+}
+
+func (cli CLI) SetGlobalVersion(goVersion string) error {
+	path, err := cli.versioner.SetGlobalVersion(goVersion)
+	if err != nil {
+		return err
+	}
+
+	cli.log.PrintMessage("%s is set as the global default version in %s", goVersion, path)
+	return nil
+}
+
+func (cli CLI) ProjectVersion(latestPatch bool) error {
+	source, err := cli.versioner.FindProjectVersion(latestPatch)
+	if err != nil {
+		return err
+	}
+
+	cli.log.PrintMessage("%s (set by %s)", source.Query, source.String())
+	return nil
+}
+
+func (cli CLI) GlobalVersion() error {
+	source, err := cli.versioner.GetGlobalVersion()
+	if err != nil {
+		return err
+	}
+
+	if source == nil {
+		return errors.New("there is no global default version, use 'gvs global <version>' to set one")
+	}
+
+	cli.log.PrintMessage(source.Query)
+	return nil
+}
+
+func (cli CLI) ExplainVersion(goVersion string, latestPatch bool) error {
+	steps, err := cli.versioner.ExplainVersion(cli.versions, goVersion, latestPatch, cli.options.IncludePrerelease)
+	if err != nil {
+		return err
+	}
+
+	for i, step := range steps {
+		state := "overridden"
+		if step.Selected {
+			state = "selected"
+		}
+
+		cli.log.PrintMessage("%d. %s (%s)", i+1, step.Source.String(), state)
+
+		if step.Source.Error != nil {
+			cli.log.PrintMessage("   error:    %s", step.Source.Error.Error())
+			continue
+		}
+		cli.log.PrintMessage("   value:    %s", step.Source.Query)
+
+		if step.Alias != "" {
+			cli.log.PrintMessage("   alias:    %s", step.Alias)
+		}
+
+		resolvedQuery := step.Source.Query
+		if step.Alias != "" {
+			resolvedQuery = step.Alias
+		}
+
+		switch {
+		case step.Semver != nil:
+			cli.log.PrintMessage("   parsed:   %s", step.Semver.GetVersion())
+		case version.IsKeyword(resolvedQuery):
+			cli.log.PrintMessage("   parsed:   keyword")
+		case version.IsConstraint(resolvedQuery):
+			cli.log.PrintMessage("   parsed:   constraint")
+		default:
+			cli.log.PrintMessage("   parsed:   invalid")
+		}
+
+		if step.Error != nil {
+			cli.log.PrintMessage("   resolved: %s", step.Error.Error())
+			continue
+		}
+
+		installed := "not installed"
+		if step.Version.AlreadyInstalled {
+			installed = "installed"
+		}
+
+		if step.Version.UsedVersion {
+			installed += ", currently used"
+		}
+
+		cli.log.PrintMessage("   resolved: %s (%s)", step.Version.Version, installed)
+	}
+
+	return nil
+}
+
+// HookEnv returns the commands that switch the given shell to the version of the current directory.
+func (cli CLI) HookEnv(shellName string) (string, error) {
+	goroot := ""
+	missing := ""
+
+	// a directory without a version is not an error for the hook, the GOROOT that was set before is removed instead.
+	if source, err := cli.versioner.FindProjectVersion(false); err == nil {
+		selectedVersion, err := cli.findVersion(source.Query)
+		if err != nil {
+			missing = source.Query
+		} else {
+			// the hook runs on every prompt, so the usage of the version is not stored (see getGoroot).
+			goroot = cli.versioner.GetVersionDirectory(selectedVersion)
+		}
+	}
+
+	return shell.HookEnv(shellName, goroot, missing)
+}
+
+func (cli CLI) EnableShims(executable string, autoInstall bool) error {
+	if err := cli.versioner.EnableShims(executable, autoInstall); err != nil {
+		return err
+	}
+
+	cli.log.PrintMessage("The shims are enabled, the go and gofmt binaries now use the version of the current directory")
+	return nil
+}
+
+func (cli CLI) DisableShims() error {
+	if err := cli.versioner.DisableShims(); err != nil {
+		return err
+	}
+
+	cli.log.PrintMessage("The shims are disabled, the go and gofmt binaries now use the version that is selected with 'gvs use'")
+	return nil
+}
+
+func (cli CLI) ShimsStatus() {
+	if cli.versioner.AreShimsEnabled() {
+		cli.log.PrintMessage("enabled")
+		return
+	}
+
+	cli.log.PrintMessage("disabled")
+}
+
+// findProjectQuery returns the version query of the current directory, or the currently used version if no version applies to it.
+func (cli CLI) findProjectQuery() (string, error) {
+	sources, err := cli.versioner.GetVersionSources(false)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sources) > 0 {
+		return sources[0].Query, nil
+	}
+
+	if currentVersion := cli.versioner.GetCurrentVersion(); currentVersion != "" {
+		return currentVersion, nil
+	}
+
+	return "", errors.New("no version found for the current directory and there is no any installed version")
+}
+
+// FindProjectGoroot returns the GOROOT of the version of the current directory (or the currently used version,
+// if no version applies to the directory), along with the version query.
+//
+// If the version is not installed, the GOROOT is empty.
+func (cli CLI) FindProjectGoroot() (string, string, error) {
+	query, err := cli.findProjectQuery()
+	if err != nil {
+		return "", "", err
+	}
+
+	selectedVersion, err := cli.findVersion(query)
+	if err != nil || !selectedVersion.AlreadyInstalled {
+		return "", query, nil
+	}
+
+	// the shims run on every call of the tools, so the usage of the version is not stored (see getGoroot).
+	return cli.versioner.GetVersionDirectory(selectedVersion), query, nil
+}
+
+// getGoroot returns the GOROOT of the given installed version, and stores that the version is used now, so the versions
+// that are used without switching to them (e.g. from `gvs exec`, `gvs shell` or `gvs matrix`) are kept from `gvs prune --keep-used-days`.
+// Since the usage is only used from `gvs prune`, the GOROOT is returned even if the usage can't be stored.
+//
+// It's used only from the commands that are run explicitly, since storing the usage on every prompt of the shell hook
+// or every call of the shims would slow them down.
+func (cli CLI) getGoroot(selectedVersion *version.ExtendedVersion) string {
+	if err := cli.versioner.UpdateVersionUsage(selectedVersion); err != nil {
+		cli.log.Error("the usage of %s can't be stored: %s\n", selectedVersion.Version, err.Error())
+	}
+
+	return cli.versioner.GetVersionDirectory(selectedVersion)
+}
+
+// Exec runs the given command with the given arguments and the given GOROOT (see gocmd.Command.NewCommand).
+//
+// The standard input and outputs are passed to the command. If the command exits with a non-zero exit code,
+// Exec returns back an error of the type *exec.ExitError.
+func (cli CLI) Exec(goroot string, name string, args []string) error {
+	cmd := cli.commander.NewCommand(goroot, name, args)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	cli.log.Info("running %s %s\n", cmd.Path, strings.Join(args, " "))
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// gvs waits for the tool to exit instead of exiting first, and the signals are forwarded to the tool, since they may be
+	// sent only to gvs (e.g. with `kill`). The interrupts from the terminal are sent to the tool as well, so the tool may receive
+	// them twice, which is the same as pressing Ctrl+C twice.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	done := make(chan struct{})
+	defer close(done)
+	defer signal.Stop(signals)
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				cmd.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return cmd.Wait()
+}
+
+// downloadIfNeeded downloads the given versions that are not already installed, one by one, without switching to them.
+// If any of the versions is downloaded, the installed versions are pruned if the stored policy is set to run after every download,
+// where the given versions are always kept.
+func (cli CLI) downloadIfNeeded(selectedVersions ...*version.ExtendedVersion) error {
+	downloaded := false
+
+	for _, selectedVersion := range selectedVersions {
+		if selectedVersion.AlreadyInstalled {
+			continue
+		}
+
+		cli.log.Info("selected %s version to download\n", selectedVersion.Version)
+		if err := cli.versioner.Download(selectedVersion, runtime.GOOS, runtime.GOARCH); err != nil {
+			return err
+		}
+
+		downloaded = true
+	}
+
+	if downloaded {
+		cli.autoPrune(selectedVersions...)
+	}
+
+	return nil
+}
+
+// ShellSession returns the commands that switch the given shell to the given version, without changing the currently used version.
+//
+// If the version is not installed, it's downloaded first, without switching to it.
+func (cli CLI) ShellSession(shellName string, goVersion string) (string, error) {
+	selectedVersion, err := cli.findVersion(goVersion)
+	if err != nil {
+		return "", err
+	}
+
+	if err := cli.downloadIfNeeded(selectedVersion); err != nil {
+		return "", err
+	}
+
+	goroot := cli.getGoroot(selectedVersion)
+	return shell.SessionEnv(shellName, strings.TrimPrefix(selectedVersion.Version, "go"), goroot)
+}
+
+// ExecVersion runs the given command with the given version (see Exec), without changing the currently used version.
+//
+// If the version is not installed, it's downloaded first, without switching to it. The version is exported to the
+// GVS_VERSION environment variable as well, so the shims that the command runs use the same version.
+func (cli CLI) ExecVersion(goVersion string, command []string) error {
+	selectedVersion, err := cli.findVersion(goVersion)
+	if err != nil {
+		return err
+	}
+
+	if err := cli.downloadIfNeeded(selectedVersion); err != nil {
+		return err
+	}
+
+	if err := os.Setenv(version.VersionEnvName, strings.TrimPrefix(selectedVersion.Version, "go")); err != nil {
+		return err
+	}
+
+	return cli.Exec(cli.getGoroot(selectedVersion), command[0], command[1:])
+}
+
+func (cli CLI) CurrentVersion() error {
+	currentVersion := cli.versioner.GetCurrentVersion()
+	if currentVersion == "" {
+		return errors.New("there is no any installed version")
+	}
+
+	cli.log.PrintMessage(currentVersion)
+	return nil
+}
+
+func New(versions []*version.ExtendedVersion, versioner version.Versioner, commander gocmd.Commander, log logger.Logger, options Options) CLI {
+	return CLI{versions: versions, versioner: versioner, commander: commander, log: log, options: options}
 }
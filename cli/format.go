@@ -0,0 +1,32 @@
+package cli
+
+import "fmt"
+
+const (
+	// TextFormat is the format that prints the output as text.
+	TextFormat = "text"
+
+	// JSONFormat is the format that prints the output as JSON.
+	JSONFormat = "json"
+
+	// TableFormat is the format that prints the output as a table.
+	TableFormat = "table"
+)
+
+// checkFormat returns an error if the given output format is not supported.
+func checkFormat(format string) error {
+	if format != TextFormat && format != JSONFormat {
+		return fmt.Errorf("%q is not a supported format, the supported formats are: %s, %s", format, TextFormat, JSONFormat)
+	}
+
+	return nil
+}
+
+// formatSize returns the given size in bytes in a human readable format (e.g. `64.3 MB`), or `-` if the size is 0.
+func formatSize(size uint64) string {
+	if size == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.1f MB", float64(size)/1024/1024)
+}
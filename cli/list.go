@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/VassilisPallas/gvs/version"
+)
+
+// listItem contains the information of a version that is printed from ListVersions.
+// The fields are available to the templates of the `--format` flag (e.g. `{{.Version}} {{.Size}}`).
+type listItem struct {
+	// Version is the name of the version (e.g. `1.21.5`).
+	Version string `json:"version"`
+
+	// IsStable indicates if the version is stable.
+	IsStable bool `json:"stable"`
+
+	// AlreadyInstalled indicates if the version is already installed.
+	AlreadyInstalled bool `json:"installed"`
+
+	// UsedVersion indicates if the version is currently used.
+	UsedVersion bool `json:"used"`
+
+	// Supported indicates if the version is supported by the Go team.
+	Supported bool `json:"supported"`
+
+	// Size is the size in bytes of the archive file for the current OS and architecture type, or 0 if there is none.
+	Size uint64 `json:"size"`
+}
+
+// formatBool returns `yes` or `no` for the given value, which is printed to the table of ListVersions.
+func formatBool(value bool) string {
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
+
+// ListVersions prints the versions that pass the given filter (see version.FilterVersions) in the given format.
+// The format can be `table`, `json`, or a Go template that is applied to every version (e.g. `{{.Version}}`),
+// where the fields of the versions are Version, IsStable, AlreadyInstalled, UsedVersion, Supported and Size.
+//
+// If the filter or the template are not valid, ListVersions returns back an error.
+func (cli CLI) ListVersions(filter version.ListFilter, format string) error {
+	versions, err := version.FilterVersions(cli.versions, filter)
+	if err != nil {
+		return err
+	}
+
+	items := make([]listItem, 0, len(versions))
+	for _, ev := range versions {
+		items = append(items, listItem{
+			Version:          strings.TrimPrefix(ev.Version, "go"),
+			IsStable:         ev.IsStable,
+			AlreadyInstalled: ev.AlreadyInstalled,
+			UsedVersion:      ev.UsedVersion,
+			Supported:        ev.SupportStatus == version.Supported,
+			Size:             ev.GetArchiveSize(runtime.GOOS, runtime.GOARCH),
+		})
+	}
+
+	var output strings.Builder
+
+	switch format {
+	case JSONFormat:
+		content, err := json.MarshalIndent(items, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		output.Write(content)
+	case TableFormat:
+		writer := tabwriter.NewWriter(&output, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tSTABLE\tINSTALLED\tUSED\tSUPPORTED\tSIZE")
+
+		for _, item := range items {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", item.Version, formatBool(item.IsStable), formatBool(item.AlreadyInstalled), formatBool(item.UsedVersion), formatBool(item.Supported), formatSize(item.Size))
+		}
+		writer.Flush()
+	default:
+		tmpl, err := template.New("list").Parse(format)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid format, use %s, %s or a Go template: %w", format, TableFormat, JSONFormat, err)
+		}
+
+		for _, item := range items {
+			if err := tmpl.Execute(&output, item); err != nil {
+				return err
+			}
+			output.WriteString("\n")
+		}
+	}
+
+	if output.Len() > 0 {
+		cli.log.PrintMessage("%s", strings.TrimSuffix(output.String(), "\n"))
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/VassilisPallas/gvs/version"
+)
+
+// matrixResult contains the result of the command for a single version of the matrix.
+type matrixResult struct {
+	// version is the name of the version (e.g. `1.21.5`).
+	version string
+
+	// err is the reason the command failed, or nil if it passed.
+	err error
+
+	// duration is how long the command ran.
+	duration time.Duration
+
+	// logPath is the path of the file that contains the output of the command.
+	logPath string
+}
+
+// runMatrixVersion runs the given command with the given version and its GOROOT, and stores the output of the command
+// to a log file inside logsDir.
+func (cli CLI) runMatrixVersion(ev *version.ExtendedVersion, goroot string, command []string, logsDir string) matrixResult {
+	name := strings.TrimPrefix(ev.Version, "go")
+	result := matrixResult{version: name, logPath: filepath.Join(logsDir, name+".log")}
+
+	logFile, err := os.Create(result.logPath)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer logFile.Close()
+
+	cmd := cli.commander.NewCommand(goroot, command[0], command[1:])
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", version.VersionEnvName, name))
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+
+	cli.log.Info("running %s with %s\n", strings.Join(command, " "), name)
+
+	start := time.Now()
+	result.err = cmd.Run()
+	result.duration = time.Since(start)
+
+	if result.err != nil {
+		fmt.Fprintf(logFile, "\ngvs: %s\n", result.err.Error())
+	}
+
+	return result
+}
+
+// RunMatrix runs the given command with every version that is described from the given queries
+// (see version.ResolveMatrix), and prints a table with the result, the duration and the log file of each version.
+//
+// The versions that are not installed are downloaded first, without switching to them. Then the command runs
+// with up to jobs versions at the same time, and the output of each run is stored to a log file inside logsDir.
+// If logsDir is empty, a temporary directory is created.
+//
+// If the command fails for any of the versions, RunMatrix returns back an error.
+func (cli CLI) RunMatrix(queries []string, command []string, jobs int, logsDir string) error {
+	versions, err := cli.versioner.ResolveMatrix(cli.versions, queries, cli.options.IncludePrerelease)
+	if err != nil {
+		return err
+	}
+
+	// the versions are downloaded before the commands run, since the downloads share the same archive file.
+	if err := cli.downloadIfNeeded(versions...); err != nil {
+		return err
+	}
+
+	// the usage is stored before the commands run at the same time, since it's stored to the same file.
+	goroots := make([]string, len(versions))
+	for i, ev := range versions {
+		goroots[i] = cli.getGoroot(ev)
+	}
+
+	if logsDir == "" {
+		logsDir, err = os.MkdirTemp("", "gvs-matrix-")
+	} else {
+		err = os.MkdirAll(logsDir, 0755)
+	}
+	if err != nil {
+		return err
+	}
+
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	results := make([]matrixResult, len(versions))
+	slots := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for i, ev := range versions {
+		wg.Add(1)
+
+		go func(i int, ev *version.ExtendedVersion) {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			result := cli.runMatrixVersion(ev, goroots[i], command, logsDir)
+			results[i] = result
+
+			mu.Lock()
+			defer mu.Unlock()
+			cli.log.PrintMessage("%s: %s (%s)", result.version, getMatrixStatus(result), result.duration.Round(100*time.Millisecond))
+		}(i, ev)
+	}
+
+	wg.Wait()
+
+	var table strings.Builder
+	writer := tabwriter.NewWriter(&table, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tRESULT\tTIME\tLOG")
+
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", result.version, getMatrixStatus(result), result.duration.Round(100*time.Millisecond), result.logPath)
+	}
+	writer.Flush()
+
+	cli.log.PrintMessage("\n%s", table.String())
+
+	if failed > 0 {
+		return fmt.Errorf("the command failed with %d of %d versions", failed, len(results))
+	}
+
+	return nil
+}
+
+// getMatrixStatus returns `pass` if the command passed for the version of the result, otherwise `fail`.
+func getMatrixStatus(result matrixResult) string {
+	if result.err != nil {
+		return "fail"
+	}
+
+	return "pass"
+}
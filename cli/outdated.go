@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/VassilisPallas/gvs/errors"
+	"github.com/VassilisPallas/gvs/version"
+)
+
+// outdatedItem is the JSON output of an installed version of Outdated.
+type outdatedItem struct {
+	// Version is the name of the installed version (e.g. `1.21.3`).
+	Version string `json:"version"`
+
+	// Current is true if the version is currently used.
+	Current bool `json:"current"`
+
+	// Latest is the newest stable patch of the release line of the version, or empty if the version is the newest one.
+	Latest string `json:"latest,omitempty"`
+
+	// Supported is true if the release line of the version is supported by the Go team.
+	Supported bool `json:"supported"`
+}
+
+// outdatedReport is the JSON output of Outdated.
+type outdatedReport struct {
+	// Versions contains the installed versions that are checked.
+	Versions []outdatedItem `json:"versions"`
+
+	// NewReleases contains the versions that are added since the last time the versions were cached.
+	NewReleases []string `json:"new_releases"`
+}
+
+// getOutdatedStatus returns the status of the given version that is printed to the cli.
+func getOutdatedStatus(outdated *version.OutdatedVersion) string {
+	statuses := []string{}
+
+	if outdated.IsOutdated() {
+		statuses = append(statuses, "outdated")
+	}
+
+	if !outdated.Supported {
+		statuses = append(statuses, "unsupported")
+	}
+
+	if len(statuses) == 0 {
+		return "up to date"
+	}
+
+	return strings.Join(statuses, ", ")
+}
+
+// Outdated prints the installed versions that have a newer stable patch version, or that are not supported
+// by the Go team anymore (see version.FindSupportedVersions), together with the given versions that are added
+// since the last time the versions were cached, in the given format (`text` or `json`).
+// If currentOnly is true, only the currently used version is checked.
+//
+// If the format is not supported, Outdated returns back an error. If any of the checked versions is outdated or unsupported,
+// Outdated returns back an error of the type *errors.OutdatedVersionsError, which has a different exit code for each case.
+func (cli CLI) Outdated(addedVersions []*version.ExtendedVersion, currentOnly bool, format string) error {
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	report := outdatedReport{Versions: []outdatedItem{}, NewReleases: []string{}}
+	outdatedErr := &errors.OutdatedVersionsError{}
+
+	var table strings.Builder
+	writer := tabwriter.NewWriter(&table, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tLATEST\tSTATUS")
+
+	for _, outdated := range version.FindOutdatedVersions(cli.versions) {
+		if currentOnly && !outdated.Version.UsedVersion {
+			continue
+		}
+
+		item := outdatedItem{
+			Version:   strings.TrimPrefix(outdated.Version.Version, "go"),
+			Current:   outdated.Version.UsedVersion,
+			Supported: outdated.Supported,
+		}
+
+		if outdated.IsOutdated() {
+			item.Latest = strings.TrimPrefix(outdated.Latest.Version, "go")
+			outdatedErr.Outdated++
+		}
+
+		if !outdated.Supported {
+			outdatedErr.Unsupported++
+		}
+
+		report.Versions = append(report.Versions, item)
+
+		name := item.Version
+		if item.Current {
+			name += " (current)"
+		}
+
+		latest := item.Latest
+		if latest == "" {
+			latest = "-"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", name, latest, getOutdatedStatus(outdated))
+	}
+	writer.Flush()
+
+	for _, ev := range addedVersions {
+		report.NewReleases = append(report.NewReleases, strings.TrimPrefix(ev.Version, "go"))
+	}
+
+	if format == JSONFormat {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		cli.log.PrintMessage("%s", output)
+	} else {
+		if len(report.Versions) == 0 {
+			cli.log.PrintMessage("there is no any installed version to check")
+		} else {
+			cli.log.PrintMessage("%s", strings.TrimSuffix(table.String(), "\n"))
+		}
+
+		if len(report.NewReleases) > 0 {
+			cli.log.PrintMessage("\nNew releases since the last check: %s", strings.Join(report.NewReleases, ", "))
+		}
+	}
+
+	if outdatedErr.Outdated > 0 || outdatedErr.Unsupported > 0 {
+		return outdatedErr
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/VassilisPallas/gvs/version"
+)
+
+// Prune deletes the installed versions that are not kept from the given policy (see version.PrunePolicy),
+// and prints the disk size that is freed. If the policy has no rules, the stored policy is used instead.
+// If dryRun is true, the versions are only printed, without deleting them.
+//
+// Every version is deleted even if another one fails. If the policy or the installed versions can't be found,
+// or any of the versions can't be deleted, Prune returns back an error.
+func (cli CLI) Prune(policy version.PrunePolicy, dryRun bool) error {
+	return cli.prune(policy, dryRun, nil)
+}
+
+// prune is the same as Prune, where the versions of the given names (e.g. `go1.21.5`) are kept as well.
+func (cli CLI) prune(policy version.PrunePolicy, dryRun bool, keep map[string]bool) error {
+	if !policy.HasRules() {
+		storedPolicy, err := cli.versioner.GetPrunePolicy()
+		if err != nil {
+			return err
+		}
+
+		policy = storedPolicy
+	}
+
+	installedVersions, err := cli.versioner.GetInstalledVersions()
+	if err != nil {
+		return err
+	}
+
+	found, err := cli.versioner.FindPrunableVersions(installedVersions, policy)
+	if err != nil {
+		return err
+	}
+
+	prunable := []*version.ExtendedVersion{}
+	for _, ev := range found {
+		if !keep[ev.Version] {
+			prunable = append(prunable, ev)
+		}
+	}
+
+	if len(prunable) == 0 {
+		cli.log.PrintMessage("Nothing to delete")
+		return nil
+	}
+
+	var freed int64
+	failed := 0
+
+	for _, ev := range prunable {
+		name := strings.TrimPrefix(ev.Version, "go")
+
+		size, err := cli.versioner.GetVersionSize(ev)
+		if err != nil {
+			cli.log.Error("the size of %s can't be found: %s\n", name, err.Error())
+		}
+
+		if dryRun {
+			cli.log.PrintMessage("%s would be deleted (%s)", name, formatSize(uint64(size)))
+			freed += size
+			continue
+		}
+
+		if err := cli.versioner.Uninstall(ev, false); err != nil {
+			cli.log.PrintError(err.Error())
+			failed++
+			continue
+		}
+
+		freed += size
+	}
+
+	if dryRun {
+		cli.log.PrintMessage("%d versions would be deleted, freeing %s", len(prunable), formatSize(uint64(freed)))
+		return nil
+	}
+
+	cli.log.PrintMessage("%d versions are deleted, %s is freed", len(prunable)-failed, formatSize(uint64(freed)))
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d versions can't be deleted", failed, len(prunable))
+	}
+
+	return nil
+}
+
+// SavePrunePolicy stores the given policy, which is used from `gvs prune` when no rules are given,
+// and after every install if the Auto field of the policy is true. If the policy has no rules, the default rules
+// are stored instead (see version.DefaultPrunePolicy), since a policy without rules would delete every other version.
+//
+// If the policy can't be stored, SavePrunePolicy returns back an error.
+func (cli CLI) SavePrunePolicy(policy version.PrunePolicy) error {
+	storedPolicy, err := cli.versioner.SetPrunePolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	if !policy.HasRules() {
+		cli.log.PrintMessage("No --keep flags are given, so the default rules are saved (--keep-latest-patches --keep-aliased --keep-pinned)")
+	}
+
+	cli.log.PrintMessage("The prune policy is saved")
+	if storedPolicy.Auto {
+		cli.log.PrintMessage("The installed versions will be pruned after every install of a new version")
+	}
+
+	return nil
+}
+
+// autoPrune prunes the installed versions with the stored policy, if the policy is set to run after every install or download.
+// The given versions are always kept, since they are the versions that are installed or downloaded to be used.
+// Since the install or the download has already succeeded, any errors are printed instead of returned.
+func (cli CLI) autoPrune(keepVersions ...*version.ExtendedVersion) {
+	policy, err := cli.versioner.GetPrunePolicy()
+	if err != nil {
+		cli.log.PrintError("the prune policy can't be read: %s", err.Error())
+		return
+	}
+
+	if !policy.Auto {
+		return
+	}
+
+	keep := map[string]bool{}
+	for _, ev := range keepVersions {
+		keep[ev.Version] = true
+	}
+
+	if err := cli.prune(policy, false, keep); err != nil {
+		cli.log.PrintError(err.Error())
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/VassilisPallas/gvs/version"
+)
+
+// Upgrade installs and switches to the newest stable patch of the release line of the current version,
+// or to the newest stable version if minor is true (see version.FindUpgradeVersion).
+// If the current version is already the newest one, Upgrade prints it and does nothing.
+//
+// If prune is true, the previous version is deleted after the upgrade, and a warning is printed
+// for the aliases and the project files that refer to it.
+//
+// If the current version can't be found, or the upgrade fails, Upgrade returns back an error.
+func (cli CLI) Upgrade(minor bool, prune bool) error {
+	currentVersion := cli.versioner.GetCurrentVersion()
+
+	selectedVersion, err := cli.versioner.FindUpgradeVersion(cli.versions, minor)
+	if err != nil {
+		return err
+	}
+
+	if selectedVersion == nil {
+		if minor {
+			cli.log.PrintMessage("%s is already the latest version, nothing to upgrade", currentVersion)
+		} else {
+			cli.log.PrintMessage("%s is already the latest patch version, nothing to upgrade", currentVersion)
+		}
+		return nil
+	}
+
+	cli.log.PrintMessage("Upgrading from %s to %s", currentVersion, strings.TrimPrefix(selectedVersion.Version, "go"))
+
+	// the exact version is searched instead of resolved, since a query like `1.20` resolves to the newest 1.20 patch.
+	var previousVersion *version.ExtendedVersion
+	for _, ev := range cli.versions {
+		if ev.Version == "go"+currentVersion {
+			previousVersion = ev
+			break
+		}
+	}
+
+	if err := cli.Install(selectedVersion); err != nil {
+		return err
+	}
+
+	if !prune {
+		return nil
+	}
+
+	if previousVersion == nil || !previousVersion.AlreadyInstalled {
+		cli.log.PrintMessage("%s is not installed, nothing to delete", currentVersion)
+		return nil
+	}
+
+	// the previous version is not used anymore after the upgrade, so it can be deleted without force.
+	previousVersion.UsedVersion = false
+
+	// the previous version is deleted directly instead of through Uninstall, since the exact version is needed.
+	failed, err := cli.uninstallVersions([]*version.ExtendedVersion{previousVersion}, false)
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("the previous version %s can't be uninstalled", currentVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,853 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/VassilisPallas/gvs/cli"
+	"github.com/VassilisPallas/gvs/files"
+	"github.com/VassilisPallas/gvs/flags"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/pkg/gocmd"
+	"github.com/VassilisPallas/gvs/shell"
+	"github.com/VassilisPallas/gvs/version"
+)
+
+// application contains the dependencies that are shared between the commands.
+type application struct {
+	fileHelpers files.FileHelpers
+	versioner   version.Versioner
+	commander   gocmd.Commander
+	log         *logger.Log
+}
+
+// newCLI returns a cli.CLI instance.
+//
+// If fetchVersions is true, the available versions are fetched (or read from the cache) before creating
+// the instance, so they are loaded only from the commands that need them.
+func (app application) newCLI(fetchVersions bool) (cli.CLI, error) {
+	if !fetchVersions {
+		return cli.New(nil, app.versioner, app.commander, app.log, app.cliOptions()), nil
+	}
+
+	versions, err := app.versioner.GetVersions(refreshVersions)
+	if err != nil {
+		return cli.CLI{}, err
+	}
+
+	return cli.New(versions, app.versioner, app.commander, app.log, app.cliOptions()), nil
+}
+
+// newInstalledCLI returns a cli.CLI instance for the installed versions only.
+//
+// The versions are read from the installed directories without fetching the available versions,
+// so it can be used from the commands that have to be fast (e.g. the shell hook).
+func (app application) newInstalledCLI() (cli.CLI, error) {
+	versions, err := app.versioner.GetInstalledVersions()
+	if err != nil {
+		return cli.CLI{}, err
+	}
+
+	return cli.New(versions, app.versioner, app.commander, app.log, app.cliOptions()), nil
+}
+
+// findToolGoroot returns the GOROOT of the version that applies to the current directory (or the currently used version,
+// if no version applies to the directory), which is resolved from the installed versions.
+//
+// If the version is not installed and install is true, the version is downloaded first, without switching to it.
+func (app application) findToolGoroot(install bool) (string, error) {
+	c, err := app.newInstalledCLI()
+	if err != nil {
+		return "", err
+	}
+
+	goroot, query, err := c.FindProjectGoroot()
+	if err != nil || goroot != "" {
+		return goroot, err
+	}
+
+	if !install {
+		return "", fmt.Errorf("%s is not installed, run 'gvs install --download-only %s' to install it", query, query)
+	}
+
+	fullCLI, err := app.newCLI(true)
+	if err != nil {
+		return "", err
+	}
+
+	if err := fullCLI.DownloadVersions([]string{query}); err != nil {
+		return "", err
+	}
+
+	c, err = app.newInstalledCLI()
+	if err != nil {
+		return "", err
+	}
+
+	goroot, _, err = c.FindProjectGoroot()
+	if err == nil && goroot == "" {
+		return "", fmt.Errorf("%s is not installed", query)
+	}
+
+	return goroot, err
+}
+
+// cliOptions returns the cli.Options based on the passed flags.
+func (app application) cliOptions() cli.Options {
+	return cli.Options{IncludePrerelease: includePrerelease}
+}
+
+// readModVersion returns the version query for the go.work or the go.mod file that applies to the current directory,
+// and prints which file and directive were used.
+func (app application) readModVersion() (string, error) {
+	source, err := app.versioner.GetModVersion(modLatestPatch)
+	if err != nil {
+		return "", err
+	}
+
+	app.log.PrintMessage("Using %s from %s", source.Query, source.String())
+	return source.Query, nil
+}
+
+// readProjectVersion returns the version query that applies to the current directory (see version.FindProjectVersion),
+// and prints where it was found.
+func (app application) readProjectVersion() (string, error) {
+	source, err := app.versioner.FindProjectVersion(modLatestPatch)
+	if err != nil {
+		return "", err
+	}
+
+	app.log.PrintMessage("Using %s from %s", source.Query, source.String())
+	return source.Query, nil
+}
+
+// checkArgs returns an error if the count of the positional arguments is not between min and max.
+// A negative max means there is no upper limit.
+func checkArgs(command string, args []string, min int, max int) error {
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return fmt.Errorf("wrong number of arguments for %q, run %q for more information", command, fmt.Sprintf("gvs %s --help", command))
+	}
+
+	return nil
+}
+
+// registerCommands registers the subcommands of the CLI.
+func (app application) registerCommands(set *flags.FlagSet) {
+	app.registerInstallCommand(set)
+	app.registerUseCommand(set)
+	app.registerListCommand(set)
+	app.registerUninstallCommand(set)
+	app.registerCurrentCommand(set)
+	app.registerAliasCommand(set)
+	app.registerLocalCommand(set)
+	app.registerGlobalCommand(set)
+	app.registerWhyCommand(set)
+	app.registerHookCommand(set)
+	app.registerHookEnvCommand(set)
+	app.registerShimsCommand(set)
+	app.registerShimExecCommand(set)
+	app.registerShellCommand(set)
+	app.registerExecCommand(set)
+	app.registerMatrixCommand(set)
+	app.registerBisectCommand(set)
+	app.registerBenchCommand(set)
+	app.registerAPIDiffCommand(set)
+	app.registerPruneCommand(set)
+	app.registerUpgradeCommand(set)
+	app.registerOutdatedCommand(set)
+}
+
+// registerInstallCommand registers the `gvs install [version]...` command.
+func (app application) registerInstallCommand(set *flags.FlagSet) {
+	var showAll bool
+	var supportedOnly bool
+	var latest bool
+	var fromMod bool
+	var downloadOnly bool
+
+	cmd := set.Command("install", "[version]...", "Install a Go version. The version can also be a keyword (e.g. 'latest', 'oldstable', 'latest-rc') or a constraint expression (e.g. '>=1.21.4 <1.23'). If no version is given, the version can be selected from the dropdown.", func(args []string) error {
+		if fromMod {
+			if err := checkArgs("install", args, 0, 0); err != nil {
+				return err
+			}
+
+			modVersion, err := app.readModVersion()
+			if err != nil {
+				return err
+			}
+
+			args = []string{modVersion}
+		}
+
+		if downloadOnly {
+			if err := checkArgs("install", args, 1, -1); err != nil {
+				return err
+			}
+
+			c, err := app.newCLI(true)
+			if err != nil {
+				return err
+			}
+
+			return c.DownloadVersions(args)
+		}
+
+		if err := checkArgs("install", args, 0, 1); err != nil {
+			return err
+		}
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case len(args) == 1:
+			return c.InstallVersion(args[0])
+		case latest:
+			return c.InstallLatestVersion()
+		default:
+			return c.SelectVersion(showAll, supportedOnly)
+		}
+	})
+
+	cmd.FlagBool(&showAll, "show-all", 'a', false, "Show both stable and unstable versions on the dropdown.")
+	cmd.FlagBool(&supportedOnly, "supported-only", 0, false, "Show only the versions that are supported by the Go team (the two latest release lines) on the dropdown.")
+	cmd.FlagBool(&latest, "latest", 'l', false, "Install latest stable version.")
+	cmd.FlagBool(&fromMod, "from-mod", 'm', false, "Install the version that will be found on the go.work or the go.mod file of the current directory or its parents. The toolchain directive is preferred over the go directive.")
+	cmd.FlagBool(&modLatestPatch, "latest-patch", 0, false, "With --from-mod, select the latest patch version of the go.mod version, instead of the exact version that the Go 1.21+ semantics describe.")
+	cmd.FlagBool(&downloadOnly, "download-only", 'd', false, "Only download, verify and extract the given versions, without switching to them. Accepts multiple versions, so they can be prefetched and activated later with 'gvs use'.")
+	cmd.FlagBool(&includePrerelease, "include-prerelease", 0, false, "Allow betas and release candidates to be selected when resolving a version or a constraint.")
+}
+
+// registerUseCommand registers the `gvs use <version>` command.
+func (app application) registerUseCommand(set *flags.FlagSet) {
+	var fromMod bool
+
+	cmd := set.Command("use", "[version]", "Switch to the given Go version, keyword (e.g. 'previous', 'oldstable') or to the newest version that satisfies the given constraint (e.g. '~1.21'). Without a version, the version of the current directory is used, from the GVS_VERSION environment variable, a .go-version or .tool-versions file, a go.work or go.mod file, or the global default version, in this order. The version is downloaded first, if it is not already downloaded.", func(args []string) error {
+		if fromMod {
+			if err := checkArgs("use", args, 0, 0); err != nil {
+				return err
+			}
+
+			modVersion, err := app.readModVersion()
+			if err != nil {
+				return err
+			}
+
+			args = []string{modVersion}
+		}
+
+		if len(args) == 0 {
+			projectVersion, err := app.readProjectVersion()
+			if err != nil {
+				return err
+			}
+
+			args = []string{projectVersion}
+		}
+
+		if err := checkArgs("use", args, 1, 1); err != nil {
+			return err
+		}
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		return c.InstallVersion(args[0])
+	})
+
+	cmd.FlagBool(&fromMod, "from-mod", 'm', false, "Use the version that will be found on the go.work or the go.mod file of the current directory or its parents. The toolchain directive is preferred over the go directive.")
+	cmd.FlagBool(&modLatestPatch, "latest-patch", 0, false, "With --from-mod, select the latest patch version of the go.mod version, instead of the exact version that the Go 1.21+ semantics describe.")
+	cmd.FlagBool(&includePrerelease, "include-prerelease", 0, false, "Allow betas and release candidates to be selected when resolving a version or a constraint.")
+}
+
+// registerListCommand registers the `gvs list` command.
+func (app application) registerListCommand(set *flags.FlagSet) {
+	var filter version.ListFilter
+	var stable bool
+	var format string
+
+	cmd := set.Command("list", "", "List the Go versions, without a prompt. By default, all the stable versions are listed as a table, with the size of the archive file for the current platform. The format can be 'table', 'json', or a Go template that is applied to every version (e.g. '{{.Version}}'), with the fields Version, IsStable, AlreadyInstalled, UsedVersion, Supported and Size.", func(args []string) error {
+		if err := checkArgs("list", args, 0, 0); err != nil {
+			return err
+		}
+
+		if filter.Installed && filter.Remote {
+			return fmt.Errorf("--installed and --remote can't be used together")
+		}
+
+		if stable && filter.ShowAll {
+			return fmt.Errorf("--stable and --all can't be used together")
+		}
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		return c.ListVersions(filter, format)
+	})
+
+	cmd.FlagBool(&filter.Installed, "installed", 'i', false, "List only the installed versions.")
+	cmd.FlagBool(&filter.Remote, "remote", 'r', false, "List only the versions that are not installed.")
+	cmd.FlagBool(&stable, "stable", 's', false, "List only the stable versions (default).")
+	cmd.FlagBool(&filter.ShowAll, "all", 'a', false, "List both stable and unstable versions.")
+	cmd.FlagBool(&filter.ShowAll, "show-all", 0, false, "The same as --all.")
+	cmd.FlagStr(&filter.Constraint, "constraint", 'c', "", "List only the versions that satisfy the given constraint (e.g. '>=1.21').")
+	cmd.FlagBool(&filter.SupportedOnly, "supported-only", 0, false, "List only the versions that are supported by the Go team (the two latest release lines).")
+	cmd.FlagStr(&format, "format", 'f', cli.TableFormat, "The format of the output, 'table', 'json', or a Go template.")
+}
+
+// registerUninstallCommand registers the `gvs uninstall <version>...` command.
+func (app application) registerUninstallCommand(set *flags.FlagSet) {
+	var unused bool
+	var force bool
+
+	cmd := set.Command("uninstall", "<version>...", "Delete the given installed Go versions (e.g. 'gvs uninstall 1.19.3 1.20.1'), where a constraint (e.g. '<1.21') deletes all the installed versions that satisfy it. The current version is deleted only with --force, and a warning is printed for the aliases and the project files that use a deleted version.", func(args []string) error {
+		if !unused {
+			if err := checkArgs("uninstall", args, 1, -1); err != nil {
+				return err
+			}
+		}
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		if len(args) > 0 {
+			if err := c.Uninstall(args, force); err != nil {
+				return err
+			}
+		}
+
+		if unused {
+			return c.DeleteUnusedVersions()
+		}
+
+		return nil
+	})
+
+	cmd.FlagBool(&unused, "unused", 'u', false, "Delete all unused versions that were installed before.")
+	cmd.FlagBool(&force, "force", 'f', false, "Delete the current version as well, along with its symlinks.")
+}
+
+// registerCurrentCommand registers the `gvs current` command.
+func (app application) registerCurrentCommand(set *flags.FlagSet) {
+	set.Command("current", "", "Print the currently used Go version.", func(args []string) error {
+		if err := checkArgs("current", args, 0, 0); err != nil {
+			return err
+		}
+
+		c, err := app.newCLI(false)
+		if err != nil {
+			return err
+		}
+
+		return c.CurrentVersion()
+	})
+}
+
+// registerAliasCommand registers the `gvs alias <set|list|rm>` command.
+func (app application) registerAliasCommand(set *flags.FlagSet) {
+	set.Command("alias", "<set <name> <version>|list|rm <name>>", "Manage named aliases for versions (e.g. 'gvs alias set work 1.21.6', then 'gvs use work').", func(args []string) error {
+		if err := checkArgs("alias", args, 1, 3); err != nil {
+			return err
+		}
+
+		c, err := app.newCLI(false)
+		if err != nil {
+			return err
+		}
+
+		switch args[0] {
+		case "set":
+			if err := checkArgs("alias", args, 3, 3); err != nil {
+				return err
+			}
+
+			return c.SetAlias(args[1], args[2])
+		case "list":
+			if err := checkArgs("alias", args, 1, 1); err != nil {
+				return err
+			}
+
+			return c.ListAliases()
+		case "rm":
+			if err := checkArgs("alias", args, 2, 2); err != nil {
+				return err
+			}
+
+			return c.DeleteAlias(args[1])
+		default:
+			return fmt.Errorf("unknown alias action %q, run %q for more information", args[0], "gvs alias --help")
+		}
+	})
+}
+
+// registerLocalCommand registers the `gvs local [version]` command.
+func (app application) registerLocalCommand(set *flags.FlagSet) {
+	set.Command("local", "[version]", "Write the given version to the .go-version file of the current directory. Without a version, print the version of the current directory and where it was found.", func(args []string) error {
+		if err := checkArgs("local", args, 0, 1); err != nil {
+			return err
+		}
+
+		c, err := app.newCLI(false)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			return c.ProjectVersion(modLatestPatch)
+		}
+
+		return c.SetLocalVersion(args[0])
+	})
+}
+
+// registerGlobalCommand registers the `gvs global [version]` command.
+func (app application) registerGlobalCommand(set *flags.FlagSet) {
+	set.Command("global", "[version]", "Set the global default version, which is used when no other version applies to the current directory. Without a version, print the global default version.", func(args []string) error {
+		if err := checkArgs("global", args, 0, 1); err != nil {
+			return err
+		}
+
+		c, err := app.newCLI(false)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			return c.GlobalVersion()
+		}
+
+		return c.SetGlobalVersion(args[0])
+	})
+}
+
+// registerWhyCommand registers the `gvs why [version]` command.
+func (app application) registerWhyCommand(set *flags.FlagSet) {
+	cmd := set.Command("why", "[version]", "Explain which version applies to the current directory and why. Every source that sets a version (the given version, the GVS_VERSION environment variable, a .go-version or .tool-versions file, a go.work or go.mod file and the global default version) is printed in the order they are looked up, along with its value, the parsed version, the version it is resolved to and whether it is installed.", func(args []string) error {
+		if err := checkArgs("why", args, 0, 1); err != nil {
+			return err
+		}
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		query := ""
+		if len(args) == 1 {
+			query = args[0]
+		}
+
+		return c.ExplainVersion(query, modLatestPatch)
+	})
+
+	cmd.FlagBool(&modLatestPatch, "latest-patch", 0, false, "Select the latest patch version of the go.mod version, instead of the exact version that the Go 1.21+ semantics describe.")
+	cmd.FlagBool(&includePrerelease, "include-prerelease", 0, false, "Allow betas and release candidates to be selected when resolving a version or a constraint.")
+}
+
+// registerHookCommand registers the `gvs hook <bash|zsh|fish>` command.
+func (app application) registerHookCommand(set *flags.FlagSet) {
+	set.Command("hook", "<bash|zsh|fish>", "Print the hook that switches the Go version of the shell when the directory changes. Add 'eval \"$(gvs hook bash)\"' to ~/.bashrc, 'eval \"$(gvs hook zsh)\"' to ~/.zshrc or 'gvs hook fish | source' to ~/.config/fish/config.fish. The version of the directory is resolved from the installed versions only, and it changes the PATH and the GOROOT of the current shell only.", func(args []string) error {
+		if err := checkArgs("hook", args, 1, 1); err != nil {
+			return err
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return err
+		}
+
+		script, err := shell.Hook(args[0], executable)
+		if err != nil {
+			return err
+		}
+
+		app.log.PrintMessage("%s", script)
+		return nil
+	})
+}
+
+// registerHookEnvCommand registers the `gvs hook-env <bash|zsh|fish>` command, which is called from the shell hook.
+func (app application) registerHookEnvCommand(set *flags.FlagSet) {
+	set.Command("hook-env", "<bash|zsh|fish>", "Print the commands that switch the shell to the version of the current directory. It is called from the shell hook (see 'gvs hook').", func(args []string) error {
+		// the standard output is evaluated from the shell, so the messages of gvs are printed to the standard error.
+		app.log.SetCliWriter(os.Stderr)
+
+		if err := checkArgs("hook-env", args, 1, 1); err != nil {
+			return err
+		}
+
+		c, err := app.newInstalledCLI()
+		if err != nil {
+			return err
+		}
+
+		script, err := c.HookEnv(args[0])
+		if err != nil {
+			return err
+		}
+
+		if script != "" {
+			fmt.Println(script)
+		}
+
+		return nil
+	})
+}
+
+// registerShimsCommand registers the `gvs shims <enable|disable|status>` command.
+func (app application) registerShimsCommand(set *flags.FlagSet) {
+	var install bool
+
+	cmd := set.Command("shims", "<enable|disable|status>", "Manage the shims, which replace the go and gofmt symlinks of $HOME/bin with launchers that resolve the version of the current directory every time they run, so different terminals can use different versions at the same time. When the shims are disabled, the symlinks of the version that is selected with 'gvs use' are created again.", func(args []string) error {
+		if err := checkArgs("shims", args, 1, 1); err != nil {
+			return err
+		}
+
+		c, err := app.newCLI(false)
+		if err != nil {
+			return err
+		}
+
+		switch args[0] {
+		case "enable":
+			executable, err := os.Executable()
+			if err != nil {
+				return err
+			}
+
+			return c.EnableShims(executable, install)
+		case "disable":
+			return c.DisableShims()
+		case "status":
+			c.ShimsStatus()
+			return nil
+		default:
+			return fmt.Errorf("unknown shims action %q, run %q for more information", args[0], "gvs shims --help")
+		}
+	})
+
+	cmd.FlagBool(&install, "install", 'i', false, "With enable, the shims download the version of the directory first, if it's not already installed.")
+}
+
+// registerShimExecCommand registers the `gvs shim-exec <tool> -- [args]...` command, which is called from the shims.
+func (app application) registerShimExecCommand(set *flags.FlagSet) {
+	var install bool
+
+	cmd := set.Command("shim-exec", "<tool> -- [args]...", "Run the given tool of the version of the current directory. It is called from the shims (see 'gvs shims').", func(args []string) error {
+		if err := checkArgs("shim-exec", args, 1, -1); err != nil {
+			return err
+		}
+
+		tool, toolArgs := args[0], args[1:]
+		if len(toolArgs) > 0 && toolArgs[0] == "--" {
+			toolArgs = toolArgs[1:]
+		}
+
+		// the standard output belongs to the tool, so the messages of gvs are printed to the standard error.
+		app.log.SetCliWriter(os.Stderr)
+
+		goroot, err := app.findToolGoroot(install)
+		if err != nil {
+			return err
+		}
+
+		c, err := app.newCLI(false)
+		if err != nil {
+			return err
+		}
+
+		return c.Exec(goroot, tool, toolArgs)
+	})
+
+	cmd.FlagBool(&install, "install", 'i', false, "Download the version first, if it's not already installed.")
+}
+
+// registerShellCommand registers the `gvs shell <version>` command.
+func (app application) registerShellCommand(set *flags.FlagSet) {
+	var unset bool
+	var shellName string
+
+	cmd := set.Command("shell", "<version>", "Print the commands that switch the current shell to the given version, without changing the version that is selected with 'gvs use'. Use it with 'eval \"$(gvs shell 1.20)\"' (or 'gvs shell 1.20 | source' on fish). The version is downloaded first, if it's not already downloaded.", func(args []string) error {
+		if shellName == "" {
+			shellName = shell.Detect()
+		}
+
+		if unset {
+			if err := checkArgs("shell", args, 0, 0); err != nil {
+				return err
+			}
+
+			script, err := shell.UnsetSessionEnv(shellName)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(script)
+			return nil
+		}
+
+		if err := checkArgs("shell", args, 1, 1); err != nil {
+			return err
+		}
+
+		// the standard output is evaluated from the shell, so the messages of gvs are printed to the standard error.
+		app.log.SetCliWriter(os.Stderr)
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		script, err := c.ShellSession(shellName, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(script)
+		return nil
+	})
+
+	cmd.FlagBool(&unset, "unset", 'u', false, "Revert the changes of a previous 'gvs shell', so the shell uses the version of the current directory again.")
+	cmd.FlagStr(&shellName, "shell", 's', "", "The shell to print the commands for (bash, zsh or fish). By default, it's detected from the SHELL environment variable.")
+}
+
+// registerExecCommand registers the `gvs exec <version> -- <command>...` command.
+func (app application) registerExecCommand(set *flags.FlagSet) {
+	cmd := set.Command("exec", "<version> -- <command>...", "Run the given command with the given version (e.g. 'gvs exec 1.21.5 -- go test ./...'), without changing the version that is selected with 'gvs use'. The version is downloaded first, if it's not already downloaded. The command runs with the GOROOT of the version and its bin directory first on the PATH, and gvs exits with the exit code of the command.", func(args []string) error {
+		if err := checkArgs("exec", args, 2, -1); err != nil {
+			return err
+		}
+
+		goVersion, command := args[0], args[1:]
+		if command[0] == "--" {
+			command = command[1:]
+		}
+
+		if len(command) == 0 {
+			return checkArgs("exec", command, 1, -1)
+		}
+
+		// the standard output belongs to the command, so the messages of gvs are printed to the standard error.
+		app.log.SetCliWriter(os.Stderr)
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		return c.ExecVersion(goVersion, command)
+	})
+
+	cmd.FlagBool(&includePrerelease, "include-prerelease", 0, false, "Allow betas and release candidates to be selected when resolving a version or a constraint.")
+}
+
+// registerMatrixCommand registers the `gvs matrix <version>... -- <command>...` command.
+func (app application) registerMatrixCommand(set *flags.FlagSet) {
+	var jobs int
+	var logsDir string
+
+	cmd := set.Command("matrix", "<version>... -- <command>...", "Run the given command with each of the given versions (e.g. 'gvs matrix 1.20 1.21 1.22 -- go test ./...') and print a pass/fail table. A constraint (e.g. '>=1.20') runs the command with the newest version of each release line that satisfies it, and 'supported' with the newest version of each release line that is supported by the Go team. The versions are downloaded first, if they are not already downloaded, and gvs exits with a non-zero exit code if the command fails with any version.", func(args []string) error {
+		separator := -1
+		for i, arg := range args {
+			if arg == "--" {
+				separator = i
+				break
+			}
+		}
+
+		if separator < 1 || separator == len(args)-1 {
+			return checkArgs("matrix", nil, 1, -1)
+		}
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		return c.RunMatrix(args[:separator], args[separator+1:], jobs, logsDir)
+	})
+
+	cmd.FlagInt(&jobs, "jobs", 'j', 2, "The count of the versions the command runs with at the same time.")
+	cmd.FlagStr(&logsDir, "logs", 0, "", "The directory where the output of each version is stored. By default, a temporary directory is created.")
+	cmd.FlagBool(&includePrerelease, "include-prerelease", 0, false, "Allow betas and release candidates to be selected when resolving a version or a constraint.")
+}
+
+// registerBisectCommand registers the `gvs bisect --good <version> --bad <version> -- <command>...` command.
+func (app application) registerBisectCommand(set *flags.FlagSet) {
+	var good string
+	var bad string
+	var skip string
+	var retries int
+
+	cmd := set.Command("bisect", "--good <version> --bad <version> -- <command>...", "Find the first release between the good and the bad version that introduced a regression (e.g. 'gvs bisect --good 1.20.5 --bad 1.21.3 -- go test ./...'), with a binary search over the releases between them. The command runs with each release that is tested, without changing the version that is selected with 'gvs use', and a release is bad if the command fails. If the command exits with 125, the release is skipped.", func(args []string) error {
+		if good == "" || bad == "" {
+			return fmt.Errorf("both --good and --bad versions are required, run %q for more information", "gvs bisect --help")
+		}
+
+		if len(args) > 0 && args[0] == "--" {
+			args = args[1:]
+		}
+
+		if err := checkArgs("bisect", args, 1, -1); err != nil {
+			return err
+		}
+
+		var skipVersions []string
+		if skip != "" {
+			skipVersions = strings.Split(skip, ",")
+		}
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		return c.Bisect(good, bad, skipVersions, args, retries)
+	})
+
+	cmd.FlagStr(&good, "good", 'g', "", "A version that doesn't have the regression.")
+	cmd.FlagStr(&bad, "bad", 'b', "", "A version that has the regression.")
+	cmd.FlagStr(&skip, "skip", 0, "", "A comma-separated list of versions that can't be tested and are skipped.")
+	cmd.FlagInt(&retries, "retries", 'r', 0, "The count of the times the command runs again when it fails, before the release is marked as bad.")
+	cmd.FlagBool(&includePrerelease, "include-prerelease", 0, false, "Include the betas and release candidates between the good and the bad version.")
+}
+
+// registerBenchCommand registers the `gvs bench <old> <new> [-- <go test arguments>...]` command.
+func (app application) registerBenchCommand(set *flags.FlagSet) {
+	var count int
+
+	cmd := set.Command("bench", "<old> <new> [-- <go test arguments>...]", "Run the benchmarks with the old and the new version (e.g. 'gvs bench 1.21 1.22 -- -bench=Parse ./...') and print the change of every measurement, with a Mann-Whitney U test to show if the change is significant. The arguments after '--' are passed to 'go test', and the versions are downloaded first, if they are not already downloaded.", func(args []string) error {
+		if err := checkArgs("bench", args, 2, -1); err != nil {
+			return err
+		}
+
+		oldVersion, newVersion, testArgs := args[0], args[1], args[2:]
+		if len(testArgs) > 0 && testArgs[0] == "--" {
+			testArgs = testArgs[1:]
+		}
+
+		c, err := app.newCLI(true)
+		if err != nil {
+			return err
+		}
+
+		return c.Bench(oldVersion, newVersion, testArgs, count)
+	})
+
+	cmd.FlagInt(&count, "count", 'c', 5, "The count of the times the benchmarks run with each version.")
+	cmd.FlagBool(&includePrerelease, "include-prerelease", 0, false, "Allow betas and release candidates to be selected when resolving a version or a constraint.")
+}
+
+// registerAPIDiffCommand registers the `gvs api-diff <old> <new>` command.
+func (app application) registerAPIDiffCommand(set *flags.FlagSet) {
+	var format string
+
+	cmd := set.Command("api-diff", "<old> <new>", "Print the standard library APIs that are added from the old to the new installed version (e.g. 'gvs api-diff 1.20 1.22'), grouped by package. The APIs are read from the GOROOT of the versions, so it works without a network connection.", func(args []string) error {
+		if err := checkArgs("api-diff", args, 2, 2); err != nil {
+			return err
+		}
+
+		c, err := app.newInstalledCLI()
+		if err != nil {
+			return err
+		}
+
+		return c.APIDiff(args[0], args[1], format)
+	})
+
+	cmd.FlagStr(&format, "format", 'f', cli.TextFormat, "The format of the output, 'text' or 'json'.")
+}
+
+// registerPruneCommand registers the `gvs prune` command.
+func (app application) registerPruneCommand(set *flags.FlagSet) {
+	var rules version.PrunePolicy
+	var dryRun bool
+	var save bool
+
+	cmd := set.Command("prune", "", "Delete the installed versions that are not kept by the retention policy. The current version is always kept. Without any --keep flags, the saved policy is used, or by default the newest version of each release line, the aliased and the pinned versions are kept. With --save, the given policy is saved instead, and with --auto it runs after every install or download of a new version.", func(args []string) error {
+		if err := checkArgs("prune", args, 0, 0); err != nil {
+			return err
+		}
+
+		if rules.Auto && !save {
+			return fmt.Errorf("--auto can only be used together with --save")
+		}
+
+		c, err := app.newCLI(false)
+		if err != nil {
+			return err
+		}
+
+		if save {
+			return c.SavePrunePolicy(rules)
+		}
+
+		return c.Prune(rules, dryRun)
+	})
+
+	cmd.FlagInt(&rules.KeepNewest, "keep-newest", 0, 0, "Keep the given count of the newest installed versions.")
+	cmd.FlagBool(&rules.KeepLatestPatches, "keep-latest-patches", 0, false, "Keep the newest installed version of each release line (e.g. the newest 1.21 and the newest 1.20).")
+	cmd.FlagInt(&rules.KeepUsedDays, "keep-used-days", 0, 0, "Keep the versions that were used or installed in the last given days, including the versions that are used without switching to them (e.g. from gvs exec or gvs shell).")
+	cmd.FlagBool(&rules.KeepAliased, "keep-aliased", 0, false, "Keep the versions that any of the aliases point to.")
+	cmd.FlagBool(&rules.KeepPinned, "keep-pinned", 0, false, "Keep the versions of the current directory and the global default version.")
+	cmd.FlagBool(&dryRun, "dry-run", 'n', false, "Print the versions that would be deleted and the disk size they use, without deleting them.")
+	cmd.FlagBool(&save, "save", 0, false, "Save the given policy, which is used when no --keep flags are given.")
+	cmd.FlagBool(&rules.Auto, "auto", 0, false, "Together with --save, prune the versions after every install or download of a new version, where the versions that are installed or downloaded are always kept.")
+}
+
+// registerUpgradeCommand registers the `gvs upgrade` command.
+func (app application) registerUpgradeCommand(set *flags.FlagSet) {
+	var minor bool
+	var prune bool
+
+	cmd := set.Command("upgrade", "", "Install and switch to the newest stable patch of the current minor version (e.g. from 1.21.3 to 1.21.5). The list of the versions is always refreshed first. If the current version is already the newest one, nothing changes.", func(args []string) error {
+		if err := checkArgs("upgrade", args, 0, 0); err != nil {
+			return err
+		}
+
+		versions, err := app.versioner.GetVersions(true)
+		if err != nil {
+			return err
+		}
+
+		c := cli.New(versions, app.versioner, app.commander, app.log, app.cliOptions())
+
+		return c.Upgrade(minor, prune)
+	})
+
+	cmd.FlagBool(&minor, "minor", 0, false, "Upgrade to the newest stable version, even if it's a newer minor version (e.g. from 1.21.3 to 1.22.1).")
+	cmd.FlagBool(&prune, "prune", 0, false, "Delete the previous version after the upgrade.")
+}
+
+// registerOutdatedCommand registers the `gvs outdated` command.
+func (app application) registerOutdatedCommand(set *flags.FlagSet) {
+	var currentOnly bool
+	var format string
+
+	cmd := set.Command("outdated", "", "Check if the installed versions have a newer patch version, or are not supported by the Go team anymore (only the two latest release lines are supported), and print the releases that are added since the last check. The list of the versions is always refreshed first. The exit code is 0 if everything is up to date, 2 if there are newer patch versions, 3 if there are unsupported versions and 4 if there are both.", func(args []string) error {
+		if err := checkArgs("outdated", args, 0, 0); err != nil {
+			return err
+		}
+
+		versions, addedVersions, err := app.versioner.RefreshVersions()
+		if err != nil {
+			return err
+		}
+
+		c := cli.New(versions, app.versioner, app.commander, app.log, app.cliOptions())
+
+		return c.Outdated(addedVersions, currentOnly, format)
+	})
+
+	cmd.FlagBool(&currentOnly, "current", 0, false, "Check only the currently used version.")
+	cmd.FlagStr(&format, "format", 'f', cli.TextFormat, "The format of the output, 'text' or 'json'.")
+}
@@ -1,44 +1,66 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/VassilisPallas/gvs/api_client"
-	"github.com/VassilisPallas/gvs/cli"
 	"github.com/VassilisPallas/gvs/clock"
 	cf "github.com/VassilisPallas/gvs/config"
+	gvsErrors "github.com/VassilisPallas/gvs/errors"
 	"github.com/VassilisPallas/gvs/files"
 	"github.com/VassilisPallas/gvs/flags"
 	"github.com/VassilisPallas/gvs/install"
 	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/pkg/gocmd"
 	"github.com/VassilisPallas/gvs/pkg/unzip"
 	"github.com/VassilisPallas/gvs/version"
-	"github.com/manifoldco/promptui"
 )
 
 var (
-	refreshVersions = false
-	installLatest   = false
-	deleteUnused    = false
-	showAllVersions = false
-	fromModFile     = false
-	specificVersion = ""
+	refreshVersions   = false
+	installLatest     = false
+	deleteUnused      = false
+	showAllVersions   = false
+	supportedOnly     = false
+	fromModFile       = false
+	specificVersion   = ""
+	includePrerelease = false
+	modLatestPatch    = false
 )
 
-func parseFlags() {
-	set := flags.FlagSet{}
+func parseFlags(set *flags.FlagSet) {
 	set.FlagBool(&showAllVersions, "show-all", 'a', false, "Show both stable and unstable versions.")
-	set.FlagBool(&installLatest, "install-latest", 'l', false, "Install latest stable version.")
-	set.FlagBool(&deleteUnused, "delete-unused", 'd', false, "Delete all unused versions that were installed before.")
+	set.FlagBool(&supportedOnly, "supported-only", 0, false, "Show only the versions that are supported by the Go team (the two latest release lines) on the dropdown.")
+	set.FlagBool(&installLatest, "install-latest", 'l', false, "Install latest stable version. Alias of 'gvs install --latest'.")
+	set.FlagBool(&deleteUnused, "delete-unused", 'd', false, "Delete all unused versions that were installed before. Alias of 'gvs uninstall --unused'.")
 	set.FlagBool(&refreshVersions, "refresh-versions", 'r', false, "Fetch again go versions in case the cached ones are stale.")
-	set.FlagStr(&specificVersion, "install-version", 'v', "", "Pass the version you want to install instead of selecting from the dropdown. If you do not specify the minor or the patch version, the latest one will be selected.")
-	set.FlagBool(&fromModFile, "from-mod", 'm', false, "Install the version that will be found on the go.mod file. The go.mod file should be on the same path you run gvs. If the version in the go.mod file do not specify the minor or the patch version, the latest one will be selected.")
+	set.FlagStr(&specificVersion, "install-version", 'v', "", "Pass the version you want to install instead of selecting from the dropdown. If you do not specify the minor or the patch version, the latest one will be selected. A constraint expression can be passed as well (e.g. '>=1.21.4 <1.23'), where the newest version that satisfies it will be selected. Alias of 'gvs install <version>'.")
+	set.FlagBool(&includePrerelease, "include-prerelease", 0, false, "Allow betas and release candidates to be selected when resolving a version or a constraint.")
+	set.FlagBool(&fromModFile, "from-mod", 'm', false, "Install the version that will be found on the go.work or the go.mod file. The files are searched from the path you run gvs up to the root of the repository. The toolchain directive is preferred over the go directive. Since Go 1.21, 'go 1.21' means 1.21.0, while for older versions the latest patch version will be selected. Alias of 'gvs install --from-mod'.")
+	set.FlagBool(&modLatestPatch, "latest-patch", 0, false, "With --from-mod, select the latest patch version of the go.mod version, instead of the exact version that the Go 1.21+ semantics describe.")
 
 	set.Parse()
 }
 
+// legacyFlags are the flags that select what gvs does when no command is passed.
+var legacyFlags = []string{"show-all", "supported-only", "install-latest", "delete-unused", "install-version", "from-mod"}
+
+// checkLegacyFlags returns an error if any of the legacy flags is passed together with a command,
+// since the command would run without them and they would be silently ignored.
+func checkLegacyFlags(set *flags.FlagSet, command string) error {
+	for _, name := range legacyFlags {
+		if set.IsSet(name) {
+			return fmt.Errorf("the --%s flag can't be used with a command, run %q to see the flags of the %s command", name, "gvs "+command+" --help", command)
+		}
+	}
+
+	return nil
+}
+
 func main() {
 	config := cf.GetConfig()
 	log := logger.New(os.Stdout, nil)
@@ -61,8 +83,6 @@ func main() {
 	log.SetLogWriter(logFile)
 	defer log.Close() // close log file after the execution
 
-	parseFlags()
-
 	httpClient := &http.Client{
 		Timeout: time.Duration(config.REQUEST_TIMEOUT) * time.Second,
 	}
@@ -71,20 +91,59 @@ func main() {
 	installer := install.New(fileHelpers, clientAPI, log)
 	versioner := version.New(fileHelpers, clientAPI, installer, log)
 
-	versions, err := versioner.GetVersions(refreshVersions)
+	commander := gocmd.New(fileHelpers)
+
+	app := application{fileHelpers: fileHelpers, versioner: versioner, commander: commander, log: log}
+
+	set := &flags.FlagSet{}
+	app.registerCommands(set)
+	parseFlags(set)
+
+	// when a command is passed (e.g. `gvs install 1.21`) the command is executed
+	// instead of the flags that were used before the commands were introduced.
+	if args := set.Args(); len(args) > 0 {
+		log.Info("%s command selected", args[0])
+
+		if err := checkLegacyFlags(set, args[0]); err != nil {
+			log.PrintError(err.Error())
+			os.Exit(1)
+			return
+		}
+
+		if err := set.Dispatch(args); err != nil {
+			// the programs that are executed from gvs print their own errors, so only their exit code is kept.
+			if exitCode, ok := gocmd.GetExitCode(err); ok {
+				os.Exit(exitCode)
+				return
+			}
+
+			// the outdated versions are already printed, so only the exit code that describes them is kept.
+			var outdatedErr *gvsErrors.OutdatedVersionsError
+			if errors.As(err, &outdatedErr) {
+				os.Exit(outdatedErr.ExitCode())
+				return
+			}
+
+			log.PrintError(err.Error())
+			os.Exit(1)
+			return
+		}
+
+		return
+	}
+
+	cli, err := app.newCLI(true)
 	if err != nil {
 		log.PrintError(err.Error())
 		os.Exit(1)
 		return
 	}
 
-	cli := cli.New(versions, versioner, log)
-
 	switch {
 	case fromModFile:
 		log.Info("install version from go.mod file option selected")
 
-		version, err := fileHelpers.ReadVersionFromMod()
+		version, err := app.readModVersion()
 		if err != nil {
 			log.PrintError(err.Error())
 			os.Exit(1)
@@ -118,28 +177,7 @@ func main() {
 	default:
 		log.Info("install version option selected\n")
 
-		promptVersions := versioner.GetPromptVersions(versions, showAllVersions)
-
-		var versionNames []string
-
-		for _, pv := range promptVersions {
-			versionNames = append(versionNames, pv.GetPromptName(showAllVersions))
-		}
-
-		prompt := promptui.Select{
-			Label: "Select go version",
-			Items: versionNames,
-			Size:  10,
-		}
-
-		selectedIndex, _, errPrompt := prompt.Run()
-		if errPrompt != nil {
-			log.PrintError(errPrompt.Error())
-			os.Exit(1)
-			return
-		}
-
-		err = cli.Install(promptVersions[selectedIndex])
+		err = cli.SelectVersion(showAllVersions, supportedOnly)
 		if err != nil {
 			log.PrintError(err.Error())
 			os.Exit(1)
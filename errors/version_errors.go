@@ -2,7 +2,10 @@
 // across the application.
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // NoInstalledVersionsError is a struct that implements the Error method,
 // so can "imitate" and error.
@@ -66,3 +69,97 @@ type ChecksumNotFoundError struct {
 func (err *ChecksumNotFoundError) Error() string {
 	return fmt.Sprintf("checksum not found for %q %q", err.OS, err.Arch)
 }
+
+// VersionNotInstalledError is a struct that implements the Error method,
+// so can "imitate" and error.
+//
+// This error should be used when an action requires a version to be installed, but it is not.
+type VersionNotInstalledError struct {
+	// Version is the version that is not installed.
+	Version string
+}
+
+// Error returns back an error message
+func (err *VersionNotInstalledError) Error() string {
+	return fmt.Sprintf("%s is not installed", err.Version)
+}
+
+// VersionInUseError is a struct that implements the Error method,
+// so can "imitate" and error.
+//
+// This error should be used when an action can't be applied on the currently used version.
+type VersionInUseError struct {
+	// Version is the currently used version.
+	Version string
+}
+
+// Error returns back an error message
+func (err *VersionInUseError) Error() string {
+	return fmt.Sprintf("%s is the current version", err.Version)
+}
+
+// VersionNotFoundError is a struct that implements the Error method,
+// so can "imitate" and error.
+//
+// This error should be used when none of the available versions matches the requested version.
+type VersionNotFoundError struct {
+	// Version is the requested version.
+	Version string
+
+	// Candidates contains the available versions that are the closest to the requested version.
+	Candidates []string
+}
+
+// Error returns back an error message
+func (err *VersionNotFoundError) Error() string {
+	if len(err.Candidates) == 0 {
+		return fmt.Sprintf("%s is not a valid version", err.Version)
+	}
+
+	return fmt.Sprintf("%s is not a valid version, the closest versions are: %s", err.Version, strings.Join(err.Candidates, ", "))
+}
+
+// AliasNotFoundError is a struct that implements the Error method,
+// so can "imitate" and error.
+//
+// This error should be used when a user-defined alias does not exist.
+type AliasNotFoundError struct {
+	// Name is the name of the alias.
+	Name string
+}
+
+// Error returns back an error message
+func (err *AliasNotFoundError) Error() string {
+	return fmt.Sprintf("alias %q does not exist", err.Name)
+}
+
+// OutdatedVersionsError is a struct that implements the Error method,
+// so can "imitate" and error.
+//
+// This error should be used when any of the installed versions has a newer patch version,
+// or is not supported anymore. The exit code of gvs is different for each case, so it can be used from scripts.
+type OutdatedVersionsError struct {
+	// Outdated is the count of the installed versions that have a newer patch version.
+	Outdated int
+
+	// Unsupported is the count of the installed versions that are not supported anymore.
+	Unsupported int
+}
+
+// Error returns back an error message
+func (err *OutdatedVersionsError) Error() string {
+	return fmt.Sprintf("%d installed versions have a newer patch version and %d installed versions are not supported", err.Outdated, err.Unsupported)
+}
+
+// ExitCode returns back the exit code of gvs for the error:
+// 2 if only newer patch versions exist, 3 if only unsupported versions exist and 4 if both exist.
+func (err *OutdatedVersionsError) ExitCode() int {
+	switch {
+	case err.Outdated > 0 && err.Unsupported > 0:
+		return 4
+	case err.Unsupported > 0:
+		return 3
+	default:
+		return 2
+	}
+}
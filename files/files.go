@@ -5,9 +5,13 @@ package files
 import (
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	ioFS "io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/VassilisPallas/gvs/api_client"
@@ -43,10 +47,30 @@ type FileHelpers interface {
 	// CreateExecutableSymlink must return a non-null error if the symlinks are created.
 	CreateExecutableSymlink(goVersionName string) error
 
+	// RemoveExecutableSymlinks removes the symlinks that point to the binaries of the given version.
+	// RemoveExecutableSymlinks must return a non-null error if any of the symlinks can't be removed.
+	RemoveExecutableSymlinks(goVersionName string) error
+
+	// AreShimsEnabled returns if the shims are used instead of the symlinks.
+	AreShimsEnabled() bool
+
+	// CreateShims replaces the binaries in the bin directory with shims that run the given gvs executable.
+	// If autoInstall is true, the shims install the version first, if it's not already installed.
+	// CreateShims must return a non-null error if any of the shims can't be created.
+	CreateShims(executable string, autoInstall bool) error
+
+	// RemoveShims removes the shims from the bin directory.
+	// RemoveShims must return a non-null error if any of the shims can't be removed.
+	RemoveShims() error
+
 	// UpdateRecentVersion updates the file where the currect (used) version is stored with the new installed version.
 	// UpdateRecentVersion must return a non-null error if the update is successful.
 	UpdateRecentVersion(goVersionName string) error
 
+	// ClearRecentVersion removes the file where the currect (used) version is stored, so there is no used version.
+	// ClearRecentVersion must return a non-null error if the file can't be removed.
+	ClearRecentVersion() error
+
 	// StoreVersionsResponse stores the response from the fetch request.
 	// StoreVersionsResponse must return a non-null error if the operation is successful.
 	StoreVersionsResponse(body []byte) error
@@ -63,6 +87,18 @@ type FileHelpers interface {
 	// GetRecentVersion should return an non empty string that contains the version name.
 	GetRecentVersion() string
 
+	// GetPreviousVersion returns the Go version that was used before the current one.
+	// GetPreviousVersion should return an empty string if there is no previous version.
+	GetPreviousVersion() string
+
+	// GetAliases returns the user-defined aliases, where the key is the alias name and the value is the version.
+	// GetAliases must return an empty map if there are no aliases, and a non-null error if the aliases can't be read.
+	GetAliases() (map[string]string, error)
+
+	// StoreAliases stores the given user-defined aliases, replacing the existing ones.
+	// StoreAliases must return a non-null error if the operation fails.
+	StoreAliases(aliases map[string]string) error
+
 	// DirectoryExists checks if the given Go version directory exists or not.
 	DirectoryExists(goVersion string) bool
 
@@ -81,7 +117,68 @@ type FileHelpers interface {
 	// a non-null error if the operation is successful.
 	GetLatestCreatedGoVersionDirectory() (string, error)
 
-	ReadVersionFromMod() (string, error)
+	// GetInstalledVersions returns the names of the installed Go version directories (e.g. `go1.21.5`).
+	// GetInstalledVersions must return a non-null error if the directory can't be read.
+	GetInstalledVersions() ([]string, error)
+
+	// GetVersionDirectory returns the path of the given Go version directory, which is the GOROOT of the version.
+	GetVersionDirectory(goVersion string) string
+
+	// GetGorootBinary returns the path of the given binary (e.g. `go`) inside the bin directory of the given GOROOT.
+	// GetGorootBinary must return an empty string if the binary doesn't exist.
+	GetGorootBinary(goroot string, name string) string
+
+	// UpdateVersionUsage stores the current time as the time the given Go version was last used.
+	// UpdateVersionUsage must return a non-null error if the time can't be stored.
+	UpdateVersionUsage(goVersion string) error
+
+	// IsVersionUsedWithin returns if the given Go version was used (or installed) in the last given days.
+	IsVersionUsedWithin(goVersion string, days int) bool
+
+	// GetVersionSize returns the disk size in bytes of the given installed Go version.
+	// GetVersionSize must return a non-null error if the directory can't be read.
+	GetVersionSize(goVersion string) (int64, error)
+
+	// GetPrunePolicy returns the stored content of the retention policy of `gvs prune`, or nil if there is none.
+	// GetPrunePolicy must return a non-null error if the file can't be read.
+	GetPrunePolicy() ([]byte, error)
+
+	// StorePrunePolicy stores the given content of the retention policy of `gvs prune`.
+	// StorePrunePolicy must return a non-null error if the operation fails.
+	StorePrunePolicy(content []byte) error
+
+	// GetAPIFeatures returns the features of the exported standard library API of the given installed Go version,
+	// from the `api/go1*.txt` files of its directory.
+	// GetAPIFeatures must return a non-null error if the files can't be read.
+	GetAPIFeatures(goVersion string) ([]string, error)
+
+	// FindVersionFile returns the path and the version of the closest `.go-version` or `.tool-versions` file,
+	// by walking up the parent directories.
+	// FindVersionFile must return empty values if none of the files is found, and a non-null error if a file can't be read.
+	FindVersionFile() (path string, version string, err error)
+
+	// WriteLocalVersion writes the given version to the `.go-version` file of the current directory and returns its path.
+	// WriteLocalVersion must return a non-null error if the operation fails.
+	WriteLocalVersion(version string) (string, error)
+
+	// GetGlobalVersion returns the path of the global default version file and the version it contains.
+	// GetGlobalVersion must return an empty version if there is no global version, and a non-null error if the file can't be read.
+	GetGlobalVersion() (path string, version string, err error)
+
+	// WriteGlobalVersion writes the given version as the global default version and returns the path of the file.
+	// WriteGlobalVersion must return a non-null error if the operation fails.
+	WriteGlobalVersion(version string) (string, error)
+
+	// FindModFile returns the path of the go.work or the go.mod file that applies to the current directory,
+	// by walking up the parent directories.
+	// FindModFile must return a non-null error if none of the files is found.
+	FindModFile() (string, error)
+
+	// ReadVersionFromMod returns the Go versions that are found on the given go.mod or go.work file,
+	// from the `go` and the `toolchain` directives. For a go.work file, the versions of the used modules are returned as well.
+	// ReadVersionFromMod must return a non-null error if any of the files can't be read or parsed,
+	// or if they contain neither the `go` nor the `toolchain` directive.
+	ReadVersionFromMod(path string) (goVersions []string, toolchains []string, err error)
 }
 
 // Helper is the struct that implements the FileHelpers interface
@@ -222,14 +319,149 @@ func (h Helper) CreateExecutableSymlink(goVersionName string) error {
 	return nil
 }
 
+// RemoveExecutableSymlinks removes the symlinks from $HOME/bin directory that point to
+// the bin directory of the given version (e.g. ~/.gvs/.go.versions/go1.21.3/bin).
+//
+// The rest of the files (e.g. the shims, or the symlinks of another version) are not removed.
+//
+// If for any reason if fails, RemoveExecutableSymlinks returns back an error.
+func (h Helper) RemoveExecutableSymlinks(goVersionName string) error {
+	versionBinDirectory := filepath.Join(getVersionsDir(h.fileSystem), goVersionName, "bin")
+
+	files, err := h.fileSystem.ReadDir(getBinDir(h.fileSystem))
+	if errors.Is(err, ioFS.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		link := filepath.Join(getBinDir(h.fileSystem), file.Name())
+
+		// the files that are not symlinks can't be read as links, so they are skipped.
+		destination, err := h.fileSystem.Readlink(link)
+		if err != nil || filepath.Dir(destination) != versionBinDirectory {
+			continue
+		}
+
+		if err := h.fileSystem.Remove(link); err != nil && !errors.Is(err, ioFS.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AreShimsEnabled returns if the shims are used instead of the symlinks, which is when the `SHIMS` file exists.
+func (h Helper) AreShimsEnabled() bool {
+	return h.fileExists(getShimsFile(h.fileSystem))
+}
+
+// getShimScript returns the content of the shim for the given tool.
+//
+// The shim runs `gvs shim-exec`, which resolves the version of the current directory and runs the binary of the tool.
+func getShimScript(executable string, tool string, autoInstall bool) string {
+	installFlag := ""
+	if autoInstall {
+		installFlag = " --install"
+	}
+
+	executable = "'" + strings.ReplaceAll(executable, "'", `'\''`) + "'"
+
+	return fmt.Sprintf("#!/bin/sh\n%s, run 'gvs shims disable' to restore the symlinks.\nexec %s shim-exec%s %s -- \"$@\"\n", shimHeader, executable, installFlag, tool)
+}
+
+// CreateShims creates the shims in $HOME/bin directory, which replace the symlinks of the `go` and the `gofmt` binaries.
+//
+// Each shim is a small script that runs the given gvs executable, so the version is resolved every time the tool runs,
+// based on the current directory and the environment, instead of being the same for every shell.
+// If the symlinks exist already, they are removed first.
+//
+// Once the shims are created, the `SHIMS` file is created as well, so the symlinks are not created again on install.
+//
+// If for any reason if fails, CreateShims returns back an error.
+func (h Helper) CreateShims(executable string, autoInstall bool) error {
+	if err := h.fileSystem.MkdirIfNotExist(getBinDir(h.fileSystem), 0755); err != nil {
+		return err
+	}
+
+	for _, tool := range shimTools {
+		link := fmt.Sprintf("%s/%s", getBinDir(h.fileSystem), tool)
+
+		// remove the symlink if exists already
+		if _, err := h.fileSystem.Lstat(link); err == nil {
+			if err := h.fileSystem.Remove(link); err != nil {
+				return err
+			}
+		}
+
+		if err := h.fileSystem.WriteFile(link, []byte(getShimScript(executable, tool, autoInstall)), 0755); err != nil {
+			return err
+		}
+
+		if err := h.fileSystem.Chmod(link, 0755); err != nil {
+			return err
+		}
+	}
+
+	return h.fileSystem.WriteFile(getShimsFile(h.fileSystem), []byte{}, 0644)
+}
+
+// RemoveShims removes the shims from $HOME/bin directory and the `SHIMS` file.
+//
+// The files that are not shims (e.g. a symlink that was created after the shims) are not removed.
+//
+// If for any reason if fails, RemoveShims returns back an error.
+func (h Helper) RemoveShims() error {
+	for _, tool := range shimTools {
+		link := fmt.Sprintf("%s/%s", getBinDir(h.fileSystem), tool)
+
+		content, err := h.fileSystem.ReadFile(link)
+		if errors.Is(err, ioFS.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if !strings.Contains(string(content), shimHeader) {
+			continue
+		}
+
+		if err := h.fileSystem.Remove(link); err != nil {
+			return err
+		}
+	}
+
+	if err := h.fileSystem.Remove(getShimsFile(h.fileSystem)); err != nil && !errors.Is(err, ioFS.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
 // UpdateRecentVersion updates the ~/.gvs/.go.versions/CURRENT file with the new installed version.
 //
 // We store the new installed version in this file, so we know which is the current used version.
+// The version that was used until now is stored in the ~/.gvs/.go.versions/PREVIOUS file,
+// so it's possible to switch back to it.
 //
 // If for any reason if fails, UpdateRecentVersion returns back an error.
 func (h Helper) UpdateRecentVersion(goVersionName string) error {
 	path := getCurrentVersionFile(h.fileSystem)
 
+	// the CURRENT file does not exist before the first install, so there is no previous version to store.
+	if content, err := h.fileSystem.ReadFile(path); err == nil {
+		previousVersion := string(content)
+
+		if previousVersion != "" && previousVersion != goVersionName {
+			if err := h.fileSystem.WriteFile(getPreviousVersionFile(h.fileSystem), content, 0644); err != nil {
+				return err
+			}
+		}
+	}
+
 	file, err := h.fileSystem.Create(path)
 	if err != nil {
 		return err
@@ -240,6 +472,18 @@ func (h Helper) UpdateRecentVersion(goVersionName string) error {
 	return err
 }
 
+// ClearRecentVersion removes the ~/.gvs/.go.versions/CURRENT file, so there is no used version
+// until another version is used.
+//
+// If for any reason if fails, ClearRecentVersion returns back an error.
+func (h Helper) ClearRecentVersion() error {
+	if err := h.fileSystem.Remove(getCurrentVersionFile(h.fileSystem)); err != nil && !errors.Is(err, ioFS.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
 // StoreVersionsResponse stores the response from the fetch request so it can be used by avoid
 // multiple requests to the API.
 //
@@ -298,6 +542,55 @@ func (h Helper) GetRecentVersion() string {
 	return string(content)
 }
 
+// GetPreviousVersion returns the Go version that was used before the current one from ~/.gvs/.go.versions/PREVIOUS
+//
+// If the file does not exist, GetPreviousVersion returns back an empty string.
+func (h Helper) GetPreviousVersion() string {
+	content, err := h.fileSystem.ReadFile(getPreviousVersionFile(h.fileSystem))
+	if err != nil {
+		return ""
+	}
+
+	return string(content)
+}
+
+// GetAliases returns the user-defined aliases from ~/.gvs/.go.versions/ALIASES
+//
+// The aliases are stored as a JSON object, where the key is the alias name and the value is the version.
+// If the file does not exist, GetAliases returns back an empty map.
+//
+// If for any reason if fails, GetAliases returns back an error.
+func (h Helper) GetAliases() (map[string]string, error) {
+	aliases := map[string]string{}
+
+	content, err := h.fileSystem.ReadFile(getAliasesFile(h.fileSystem))
+	if err != nil {
+		if errors.Is(err, ioFS.ErrNotExist) {
+			return aliases, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &aliases); err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+// StoreAliases stores the given user-defined aliases in ~/.gvs/.go.versions/ALIASES
+//
+// If for any reason if fails, StoreAliases returns back an error.
+func (h Helper) StoreAliases(aliases map[string]string) error {
+	content, err := json.MarshalIndent(aliases, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return h.fileSystem.WriteFile(getAliasesFile(h.fileSystem), content, 0644)
+}
+
 // DirectoryExists checks if the given Go version directory exists or not.
 func (h Helper) DirectoryExists(goVersion string) bool {
 	target := getVersionsDir(h.fileSystem)
@@ -374,18 +667,532 @@ func (h Helper) GetLatestCreatedGoVersionDirectory() (string, error) {
 	return dirName, nil
 }
 
-func (h Helper) ReadVersionFromMod() (string, error) {
-	buf, err := h.fileSystem.ReadFile("./go.mod")
+// GetInstalledVersions returns the names of the installed Go version directories (e.g. `go1.21.5`),
+// without fetching the available versions.
+//
+// If for any reason if fails, GetInstalledVersions returns back an error.
+func (h Helper) GetInstalledVersions() ([]string, error) {
+	entries, err := h.fileSystem.ReadDir(getVersionsDir(h.fileSystem))
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), "go") {
+			versions = append(versions, entry.Name())
+		}
+	}
+
+	return versions, nil
+}
+
+// GetVersionDirectory returns the path of the given Go version directory (e.g. `$HOME/.gvs/.go.versions/go1.21.5`),
+// which is the GOROOT of the version.
+func (h Helper) GetVersionDirectory(goVersion string) string {
+	return fmt.Sprintf("%s/%s", getVersionsDir(h.fileSystem), goVersion)
+}
+
+// GetGorootBinary returns the path of the given binary (e.g. `go`) inside the bin directory of the given GOROOT
+// (e.g. `$HOME/.gvs/.go.versions/go1.21.5/bin/go`).
+//
+// If the binary doesn't exist, GetGorootBinary returns back an empty string.
+func (h Helper) GetGorootBinary(goroot string, name string) string {
+	binary := filepath.Join(goroot, "bin", name)
+	if !h.fileExists(binary) {
+		return ""
+	}
+
+	return binary
+}
+
+// getVersionUsage returns the time each Go version was last used, from the `USAGE` file.
+//
+// If the file doesn't exist, getVersionUsage returns back an empty map.
+func (h Helper) getVersionUsage() (map[string]time.Time, error) {
+	usage := map[string]time.Time{}
+
+	content, err := h.fileSystem.ReadFile(getUsageFile(h.fileSystem))
+	if err != nil {
+		if errors.Is(err, ioFS.ErrNotExist) {
+			return usage, nil
+		}
+
+		return nil, err
+	}
+
+	// the file is replaced on the next update, so an invalid file (e.g. written from an older gvs version) is treated as empty.
+	if err := json.Unmarshal(content, &usage); err != nil {
+		h.log.Error("the usage file is not valid: %s\n", err.Error())
+		return map[string]time.Time{}, nil
+	}
+
+	return usage, nil
+}
+
+// UpdateVersionUsage stores the current time as the time the given Go version (e.g. `go1.21.5`) was last used,
+// to the `USAGE` file inside the `.go.versions` directory.
+//
+// The content is written to a temporary file that replaces the `USAGE` file, so the commands that run at the same time
+// never read (or write) a partially written file.
+//
+// If for any reason if fails, UpdateVersionUsage returns back an error.
+func (h Helper) UpdateVersionUsage(goVersion string) error {
+	usage, err := h.getVersionUsage()
+	if err != nil {
+		return err
+	}
+
+	usage[goVersion] = h.clock.Now()
+
+	content, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := getUsageFile(h.fileSystem)
+	tmpPath := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+
+	if err := h.fileSystem.WriteFile(tmpPath, content, 0644); err != nil {
+		return err
+	}
+
+	if err := h.fileSystem.Rename(tmpPath, path); err != nil {
+		h.fileSystem.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+// IsVersionUsedWithin returns if the given Go version (e.g. `go1.21.5`) was used in the last given days.
+//
+// The versions that were never used since the usage is stored (e.g. they were installed from an older gvs version),
+// or all of them if the usage can't be read, fall back to the modification time of their directory,
+// which is the time they were installed.
+func (h Helper) IsVersionUsedWithin(goVersion string, days int) bool {
+	usage, err := h.getVersionUsage()
+	if err != nil {
+		h.log.Error("the usage file can't be read: %s\n", err.Error())
+		usage = map[string]time.Time{}
+	}
+
+	lastUsed, ok := usage[goVersion]
+	if !ok {
+		info, err := h.fileSystem.Stat(h.GetVersionDirectory(goVersion))
+		if err != nil {
+			return false
+		}
+
+		lastUsed = info.ModTime()
+	}
+
+	return h.clock.GetDiffInHoursFromNow(lastUsed) < float64(24*days)
+}
+
+// getDirectorySize returns the size in bytes of all the files inside the given directory and its subdirectories.
+func (h Helper) getDirectorySize(path string) (int64, error) {
+	entries, err := h.fileSystem.ReadDir(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirSize, err := h.getDirectorySize(fmt.Sprintf("%s/%s", path, entry.Name()))
+			if err != nil {
+				return 0, err
+			}
+
+			size += dirSize
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return 0, err
+		}
+
+		size += info.Size()
+	}
+
+	return size, nil
+}
+
+// GetVersionSize returns the disk size in bytes of the given installed Go version (e.g. `go1.21.5`).
+//
+// If for any reason if fails, GetVersionSize returns back an error.
+func (h Helper) GetVersionSize(goVersion string) (int64, error) {
+	return h.getDirectorySize(h.GetVersionDirectory(goVersion))
+}
+
+// GetPrunePolicy returns the content of the `PRUNE` file inside the `.gvs` directory,
+// which contains the retention policy of `gvs prune`. If the file doesn't exist, GetPrunePolicy returns back nil.
+//
+// If for any reason if fails, GetPrunePolicy returns back an error.
+func (h Helper) GetPrunePolicy() ([]byte, error) {
+	content, err := h.fileSystem.ReadFile(getPrunePolicyFile(h.fileSystem))
+	if err != nil {
+		if errors.Is(err, ioFS.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// StorePrunePolicy writes the given content to the `PRUNE` file inside the `.gvs` directory.
+//
+// If for any reason if fails, StorePrunePolicy returns back an error.
+func (h Helper) StorePrunePolicy(content []byte) error {
+	return h.fileSystem.WriteFile(getPrunePolicyFile(h.fileSystem), content, 0644)
+}
+
+// GetAPIFeatures returns the features of the exported standard library API of the given installed Go version
+// (e.g. `pkg bytes, func Clone([]uint8) []uint8`), from the `api/go1*.txt` files of its directory.
+// Each file contains the features that were added with a release, and the issue numbers at the end of the lines are removed.
+//
+// If for any reason if fails, GetAPIFeatures returns back an error.
+func (h Helper) GetAPIFeatures(goVersion string) ([]string, error) {
+	apiDir := fmt.Sprintf("%s/api", h.GetVersionDirectory(goVersion))
+
+	entries, err := h.fileSystem.ReadDir(apiDir)
+	if err != nil {
+		return nil, err
+	}
+
+	features := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), "go1") || !strings.HasSuffix(entry.Name(), ".txt") {
+			continue
+		}
+
+		content, err := h.fileSystem.ReadFile(fmt.Sprintf("%s/%s", apiDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range strings.Split(string(content), "\n") {
+			feature, _, _ := strings.Cut(line, " #")
+			feature = strings.TrimSpace(feature)
+
+			if feature != "" && !strings.HasPrefix(feature, "#") {
+				features = append(features, feature)
+			}
+		}
+	}
+
+	return features, nil
+}
+
+// fileExists returns if the given path exists.
+func (h Helper) fileExists(path string) bool {
+	_, err := h.fileSystem.Stat(path)
+	return err == nil
+}
+
+// isVCSRoot returns if the given directory is the root of a repository.
+func (h Helper) isVCSRoot(dir string) bool {
+	for _, vcsDir := range vcsDirs {
+		if h.fileExists(filepath.Join(dir, vcsDir)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// walkUp calls visit for the current directory and each one of its parent directories, until visit returns true,
+// or the root of the filesystem or the root of the repository (a directory that contains a `.git`, `.hg`, `.svn`
+// or `.bzr` directory) is visited.
+//
+// If the current directory can't be found, walkUp returns back an error.
+func (h Helper) walkUp(visit func(dir string) bool) error {
+	dir, err := h.fileSystem.Getwd()
+	if err != nil {
+		return err
+	}
+
+	for {
+		if visit(dir) {
+			return nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir || h.isVCSRoot(dir) {
+			return nil
+		}
+
+		dir = parent
+	}
+}
+
+// FindModFile returns the path of the go.work or the go.mod file that applies to the current directory.
+//
+// The parent directories are walked up until the root of the filesystem, or the root of the repository
+// (a directory that contains a `.git`, `.hg`, `.svn` or `.bzr` directory).
+// A go.work file is preferred over a go.mod file, even if the go.mod file is closer to the current directory,
+// since the workspace contains the module.
+//
+// If none of the files is found, FindModFile returns back an error.
+func (h Helper) FindModFile() (string, error) {
+	workFile := ""
+	modFile := ""
+
+	err := h.walkUp(func(dir string) bool {
+		if candidate := filepath.Join(dir, goWorkFileName); h.fileExists(candidate) {
+			workFile = candidate
+			return true
+		}
+
+		if candidate := filepath.Join(dir, goModFileName); modFile == "" && h.fileExists(candidate) {
+			modFile = candidate
+		}
+
+		return false
+	})
 	if err != nil {
 		return "", err
 	}
 
-	f, err := modfile.Parse("go.mod", buf, nil)
+	if workFile != "" {
+		return workFile, nil
+	}
+
+	if modFile == "" {
+		return "", errors.New("go.mod or go.work file not found in the current directory or any of its parent directories")
+	}
+
+	return modFile, nil
+}
+
+// parseGoVersionFile returns the version of a `.go-version` file, which is the first line
+// that is not empty or a comment.
+func parseGoVersionFile(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+
+	return ""
+}
+
+// parseToolVersionsFile returns the Go version of a `.tool-versions` file, from the `golang` (or `go`) line.
+// If there are multiple versions in the line, the first one is returned, since the rest are fallbacks.
+func parseToolVersionsFile(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && (fields[0] == "golang" || fields[0] == "go") {
+			return fields[1]
+		}
+	}
+
+	return ""
+}
+
+// FindVersionFile returns the path and the version of the closest `.go-version` or `.tool-versions` file
+// that contains a Go version.
+//
+// The parent directories are walked up the same way as FindModFile does. In each directory,
+// the `.go-version` file is preferred over the `.tool-versions` file.
+// A `.tool-versions` file without a `golang` line is skipped.
+//
+// If none of the files is found, FindVersionFile returns back empty values.
+// If for any reason if fails, FindVersionFile returns back an error.
+func (h Helper) FindVersionFile() (path string, version string, err error) {
+	var readErr error
+
+	err = h.walkUp(func(dir string) bool {
+		for _, file := range []struct {
+			name  string
+			parse func(content []byte) string
+		}{
+			{name: goVersionFileName, parse: parseGoVersionFile},
+			{name: toolVersionsFileName, parse: parseToolVersionsFile},
+		} {
+			candidate := filepath.Join(dir, file.name)
+			if !h.fileExists(candidate) {
+				continue
+			}
+
+			content, err := h.fileSystem.ReadFile(candidate)
+			if err != nil {
+				readErr = err
+				return true
+			}
+
+			if v := file.parse(content); v != "" {
+				path = candidate
+				version = v
+				return true
+			}
+		}
+
+		return false
+	})
 	if err != nil {
+		return "", "", err
+	}
+
+	if readErr != nil {
+		return "", "", readErr
+	}
+
+	return path, version, nil
+}
+
+// WriteLocalVersion writes the given version to the `.go-version` file of the current directory,
+// and returns back the path of the file.
+//
+// If for any reason if fails, WriteLocalVersion returns back an error.
+func (h Helper) WriteLocalVersion(version string) (string, error) {
+	dir, err := h.fileSystem.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, goVersionFileName)
+	if err := h.fileSystem.WriteFile(path, []byte(version+"\n"), 0644); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// GetGlobalVersion returns the global default version from ~/.gvs/.go-version, along with the path of the file.
+//
+// If the file does not exist, GetGlobalVersion returns back an empty version.
+// If for any reason if fails, GetGlobalVersion returns back an error.
+func (h Helper) GetGlobalVersion() (path string, version string, err error) {
+	path = getGlobalVersionFile(h.fileSystem)
+
+	content, err := h.fileSystem.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, ioFS.ErrNotExist) {
+			return path, "", nil
+		}
+
+		return "", "", err
+	}
+
+	return path, parseGoVersionFile(content), nil
+}
+
+// WriteGlobalVersion writes the given version to ~/.gvs/.go-version, and returns back the path of the file.
+//
+// If for any reason if fails, WriteGlobalVersion returns back an error.
+func (h Helper) WriteGlobalVersion(version string) (string, error) {
+	path := getGlobalVersionFile(h.fileSystem)
+	if err := h.fileSystem.WriteFile(path, []byte(version+"\n"), 0644); err != nil {
 		return "", err
 	}
 
-	return f.Go.Version, nil
+	return path, nil
+}
+
+// getToolchainVersion returns the version of the toolchain name (e.g. `go1.21.5` or `go1.21.5-custom`),
+// without the `go` prefix and the custom suffix.
+func getToolchainVersion(name string) string {
+	version, _, _ := strings.Cut(strings.TrimPrefix(name, "go"), "-")
+	return version
+}
+
+// readVersionFromModFile returns the versions of the `go` and the `toolchain` directives of the given go.mod file.
+// The versions are empty if the directives are missing.
+func (h Helper) readVersionFromModFile(path string) (goVersion string, toolchain string, err error) {
+	buf, err := h.fileSystem.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	f, err := modfile.Parse(path, buf, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	if f.Go != nil {
+		goVersion = f.Go.Version
+	}
+
+	if f.Toolchain != nil {
+		toolchain = getToolchainVersion(f.Toolchain.Name)
+	}
+
+	return goVersion, toolchain, nil
+}
+
+// ReadVersionFromMod returns the Go versions that are found on the given go.mod or go.work file,
+// from both the `go` and the `toolchain` directives.
+//
+// For a go.work file, the versions of the go.work file and of every module in the `use` directives are returned,
+// so the highest requirement of the workspace can be selected.
+// The toolchain names (e.g. `go1.21.5` or `go1.21.5-custom`) are returned without the `go` prefix and the suffix.
+//
+// If for any reason if fails, or the files contain neither of the directives, ReadVersionFromMod returns back an error.
+func (h Helper) ReadVersionFromMod(path string) (goVersions []string, toolchains []string, err error) {
+	addVersions := func(goVersion string, toolchain string) {
+		if goVersion != "" {
+			goVersions = append(goVersions, goVersion)
+		}
+
+		if toolchain != "" {
+			toolchains = append(toolchains, toolchain)
+		}
+	}
+
+	if filepath.Base(path) == goWorkFileName {
+		buf, err := h.fileSystem.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		f, err := modfile.ParseWork(path, buf, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if f.Go != nil {
+			addVersions(f.Go.Version, "")
+		}
+
+		if f.Toolchain != nil {
+			addVersions("", getToolchainVersion(f.Toolchain.Name))
+		}
+
+		for _, use := range f.Use {
+			modPath := use.Path
+			if !filepath.IsAbs(modPath) {
+				modPath = filepath.Join(filepath.Dir(path), modPath)
+			}
+
+			goVersion, toolchain, err := h.readVersionFromModFile(filepath.Join(modPath, goModFileName))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			addVersions(goVersion, toolchain)
+		}
+	} else {
+		goVersion, toolchain, err := h.readVersionFromModFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		addVersions(goVersion, toolchain)
+	}
+
+	if len(goVersions) == 0 && len(toolchains) == 0 {
+		return nil, nil, fmt.Errorf("%s does not contain a go or a toolchain directive", path)
+	}
+
+	return goVersions, toolchains, nil
 }
 
 // New returns a *Helper instance that implements the FileHelpers interface.
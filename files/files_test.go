@@ -18,6 +18,7 @@ import (
 	"github.com/VassilisPallas/gvs/internal/testutils"
 	"github.com/VassilisPallas/gvs/logger"
 	"github.com/VassilisPallas/gvs/pkg/unzip"
+	"github.com/google/go-cmp/cmp"
 )
 
 func createFileHelper(cliWriter io.Writer, logWriter io.WriteCloser, fs testutils.FakeFileSystem, unzipper unzip.Unzipper, clock clock.Clock) *files.Helper {
@@ -420,12 +421,50 @@ func TestCreateExecutableSymlink(t *testing.T) {
 	}
 }
 
+func TestRemoveExecutableSymlinks(t *testing.T) {
+	fs := testutils.FakeFileSystem{
+		HomeDir: "/tmp",
+		ReadDirMockResponse: getDirEntries([]testutils.FakeDirEntry{
+			{DirEntryName: "go"},
+			{DirEntryName: "gofmt"},
+			{DirEntryName: "gopls"},
+			{DirEntryName: "script"},
+		}),
+		Files: map[string][]byte{
+			"/tmp/bin/go":     {},
+			"/tmp/bin/gofmt":  {},
+			"/tmp/bin/gopls":  {},
+			"/tmp/bin/script": []byte("not a symlink"),
+		},
+		Links: map[string]string{
+			"/tmp/bin/go":    "/tmp/.gvs/.go.versions/go1.21.0/bin/go",
+			"/tmp/bin/gofmt": "/tmp/.gvs/.go.versions/go1.21.0/bin/gofmt",
+			"/tmp/bin/gopls": "/tmp/go/bin/gopls",
+		},
+	}
+
+	fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+	err := fileHelper.RemoveExecutableSymlinks("go1.21.0")
+
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	expectedFiles := map[string][]byte{"/tmp/bin/gopls": {}, "/tmp/bin/script": []byte("not a symlink")}
+	if !cmp.Equal(fs.Files, expectedFiles) {
+		t.Errorf("Wrong files left, got=%s", cmp.Diff(fs.Files, expectedFiles))
+	}
+}
+
 func TestUpdateRecentVersion(t *testing.T) {
 	testCases := []struct {
-		testTitle        string
-		createError      error
-		writeToFileError error
-		expectedError    error
+		testTitle              string
+		createError            error
+		writeToFileError       error
+		currentVersion         []byte
+		writePreviousFileError error
+		expectedError          error
 	}{
 		{
 			testTitle:        "should update the recent file content",
@@ -445,6 +484,18 @@ func TestUpdateRecentVersion(t *testing.T) {
 			writeToFileError: errors.New("an error occurred while writing on the file"),
 			expectedError:    errors.New("an error occurred while writing on the file"),
 		},
+		{
+			testTitle:              "should return an error when the previous version can't be stored",
+			currentVersion:         []byte("go1.20.7"),
+			writePreviousFileError: errors.New("an error occurred while writing the previous version"),
+			expectedError:          errors.New("an error occurred while writing the previous version"),
+		},
+		{
+			testTitle:              "should not store the previous version when it's the same as the new version",
+			currentVersion:         []byte("go1.21.0"),
+			writePreviousFileError: errors.New("an error occurred while writing the previous version"),
+			expectedError:          nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -453,6 +504,8 @@ func TestUpdateRecentVersion(t *testing.T) {
 				HomeDir:          "/tmp",
 				CreateError:      tc.createError,
 				WriteStringError: tc.writeToFileError,
+				ReadFileBytes:    tc.currentVersion,
+				WriteFileError:   tc.writePreviousFileError,
 			}
 
 			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
@@ -470,6 +523,59 @@ func TestUpdateRecentVersion(t *testing.T) {
 	}
 }
 
+func TestClearRecentVersion(t *testing.T) {
+	testCases := []struct {
+		testTitle     string
+		files         map[string][]byte
+		removeError   error
+		expectedError error
+	}{
+		{
+			testTitle:     "should remove the recent file",
+			files:         map[string][]byte{"/tmp/.gvs/.go.versions/CURRENT": []byte("go1.21.0")},
+			expectedError: nil,
+		},
+		{
+			testTitle:     "should not return an error when there is no recent file",
+			files:         map[string][]byte{},
+			expectedError: nil,
+		},
+		{
+			testTitle:     "should return an error when the file can't be removed",
+			files:         map[string][]byte{"/tmp/.gvs/.go.versions/CURRENT": []byte("go1.21.0")},
+			removeError:   errors.New("an error occurred while removing the file"),
+			expectedError: errors.New("an error occurred while removing the file"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:     "/tmp",
+				Files:       tc.files,
+				RemoveError: tc.removeError,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			err := fileHelper.ClearRecentVersion()
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if tc.expectedError == nil && fileHelper.GetRecentVersion() != "" {
+				t.Errorf("there should be no recent version, instead got %q", fileHelper.GetRecentVersion())
+			}
+		})
+	}
+}
+
 func TestStoreVersionsResponse(t *testing.T) {
 	testCases := []struct {
 		testTitle        string
@@ -708,6 +814,143 @@ func TestGetRecentVersion(t *testing.T) {
 	}
 }
 
+func TestGetPreviousVersion(t *testing.T) {
+	testCases := []struct {
+		testTitle      string
+		readFileError  error
+		fileContent    []byte
+		expectedResult string
+	}{
+		{
+			testTitle:      "should return the content from the file",
+			readFileError:  nil,
+			fileContent:    []byte("go1.20.7"),
+			expectedResult: "go1.20.7",
+		},
+		{
+			testTitle:      "should return an empty string when the file does not exist",
+			readFileError:  errors.New("file does not exist"),
+			fileContent:    nil,
+			expectedResult: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:       "/tmp",
+				ReadFileBytes: tc.fileContent,
+				ReadFileError: tc.readFileError,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			res := fileHelper.GetPreviousVersion()
+
+			if res != tc.expectedResult {
+				t.Errorf("result should be %q, instead got %q", tc.expectedResult, res)
+			}
+		})
+	}
+}
+
+func TestGetAliases(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		readFileError   error
+		fileContent     []byte
+		expectedAliases map[string]string
+		expectedError   error
+	}{
+		{
+			testTitle:       "should return the aliases from the file",
+			fileContent:     []byte(`{"work": "1.21.6", "legacy": "1.19"}`),
+			expectedAliases: map[string]string{"work": "1.21.6", "legacy": "1.19"},
+			expectedError:   nil,
+		},
+		{
+			testTitle:       "should return an empty map when the file does not exist",
+			readFileError:   ioFS.ErrNotExist,
+			expectedAliases: map[string]string{},
+			expectedError:   nil,
+		},
+		{
+			testTitle:       "should return an error when the file can't be read",
+			readFileError:   errors.New("permission denied"),
+			expectedAliases: nil,
+			expectedError:   errors.New("permission denied"),
+		},
+		{
+			testTitle:       "should return an error when the file content is not valid",
+			fileContent:     []byte(`not json`),
+			expectedAliases: nil,
+			expectedError:   errors.New("invalid character 'o' in literal null (expecting 'u')"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:       "/tmp",
+				ReadFileBytes: tc.fileContent,
+				ReadFileError: tc.readFileError,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			aliases, err := fileHelper.GetAliases()
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(aliases, tc.expectedAliases) {
+				t.Errorf("Wrong aliases received, got=%s", cmp.Diff(aliases, tc.expectedAliases))
+			}
+		})
+	}
+}
+
+func TestStoreAliases(t *testing.T) {
+	testCases := []struct {
+		testTitle        string
+		writeToFileError error
+	}{
+		{
+			testTitle:        "should store the aliases to the file",
+			writeToFileError: nil,
+		},
+		{
+			testTitle:        "should return an error when the file can't be written",
+			writeToFileError: errors.New("an error occurred while writing to the file"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:        "/tmp",
+				WriteFileError: tc.writeToFileError,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			err := fileHelper.StoreAliases(map[string]string{"work": "1.21.6"})
+
+			if tc.writeToFileError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+			}
+
+			if tc.writeToFileError != nil && (err == nil || err.Error() != tc.writeToFileError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.writeToFileError.Error(), err)
+			}
+		})
+	}
+}
+
 func TestDirectoryExists(t *testing.T) {
 	testCases := []struct {
 		testTitle      string
@@ -985,32 +1228,86 @@ func TestReadVersionFromMod(t *testing.T) {
 	`)
 
 	testCases := []struct {
-		testTitle       string
-		modFileBytes    []byte
-		modeFileError   error
-		expectedError   error
-		expectedVersion string
+		testTitle          string
+		path               string
+		modFileBytes       []byte
+		modeFileError      error
+		files              map[string][]byte
+		expectedError      error
+		expectedVersions   []string
+		expectedToolchains []string
 	}{
 		{
-			testTitle:       "should return an error when reading the go.mod file returns an error",
-			modFileBytes:    nil,
-			modeFileError:   errors.New("some error while reading th go.mod file"),
-			expectedError:   errors.New("some error while reading th go.mod file"),
-			expectedVersion: "",
+			testTitle:        "should return an error when reading the go.mod file returns an error",
+			path:             "go.mod",
+			modFileBytes:     nil,
+			modeFileError:    errors.New("some error while reading th go.mod file"),
+			expectedError:    errors.New("some error while reading th go.mod file"),
+			expectedVersions: nil,
 		},
 		{
-			testTitle:       "should return an error when parsing the go.mod file returns an error",
-			modFileBytes:    []byte(`wrong`),
-			modeFileError:   nil,
-			expectedError:   errors.New("go.mod:1: unknown directive: wrong"),
-			expectedVersion: "",
+			testTitle:        "should return an error when parsing the go.mod file returns an error",
+			path:             "go.mod",
+			modFileBytes:     []byte(`wrong`),
+			modeFileError:    nil,
+			expectedError:    errors.New("go.mod:1: unknown directive: wrong"),
+			expectedVersions: nil,
 		},
 		{
-			testTitle:       "should return the version from go.mod",
-			modFileBytes:    file_bytes,
-			modeFileError:   nil,
-			expectedError:   nil,
-			expectedVersion: "1.20",
+			testTitle:        "should return the version from go.mod",
+			path:             "go.mod",
+			modFileBytes:     file_bytes,
+			modeFileError:    nil,
+			expectedError:    nil,
+			expectedVersions: []string{"1.20"},
+		},
+		{
+			testTitle:          "should return the version and the toolchain from go.mod",
+			path:               "go.mod",
+			modFileBytes:       []byte("module module_name\n\ngo 1.21\n\ntoolchain go1.21.5\n"),
+			modeFileError:      nil,
+			expectedError:      nil,
+			expectedVersions:   []string{"1.21"},
+			expectedToolchains: []string{"1.21.5"},
+		},
+		{
+			testTitle:          "should return the toolchain without the custom suffix",
+			path:               "go.mod",
+			modFileBytes:       []byte("module module_name\n\ngo 1.21.0\n\ntoolchain go1.22.1-custom\n"),
+			modeFileError:      nil,
+			expectedError:      nil,
+			expectedVersions:   []string{"1.21.0"},
+			expectedToolchains: []string{"1.22.1"},
+		},
+		{
+			testTitle:        "should return an error when go.mod does not contain any directive",
+			path:             "go.mod",
+			modFileBytes:     []byte("module module_name\n"),
+			modeFileError:    nil,
+			expectedError:    errors.New("go.mod does not contain a go or a toolchain directive"),
+			expectedVersions: nil,
+		},
+		{
+			testTitle: "should return the versions of the go.work file and its modules",
+			path:      "/repo/go.work",
+			files: map[string][]byte{
+				"/repo/go.work":       []byte("go 1.21\n\ntoolchain go1.21.3\n\nuse (\n\t./api\n\t./cli\n)\n"),
+				"/repo/api/go.mod":    []byte("module api\n\ngo 1.22.0\n"),
+				"/repo/cli/go.mod":    []byte("module cli\n\ngo 1.21.4\n\ntoolchain go1.22.1\n"),
+				"/repo/unused/go.mod": []byte("module unused\n\ngo 1.23.0\n"),
+			},
+			expectedError:      nil,
+			expectedVersions:   []string{"1.21", "1.22.0", "1.21.4"},
+			expectedToolchains: []string{"1.21.3", "1.22.1"},
+		},
+		{
+			testTitle: "should return an error when a module of the go.work file does not exist",
+			path:      "/repo/go.work",
+			files: map[string][]byte{
+				"/repo/go.work": []byte("go 1.21\n\nuse ./api\n"),
+			},
+			expectedError:    ioFS.ErrNotExist,
+			expectedVersions: nil,
 		},
 	}
 
@@ -1020,6 +1317,7 @@ func TestReadVersionFromMod(t *testing.T) {
 				HomeDir:       "/tmp",
 				ReadFileBytes: tc.modFileBytes,
 				ReadFileError: tc.modeFileError,
+				Files:         tc.files,
 			}
 
 			clock := testutils.FakeClock{
@@ -1027,22 +1325,877 @@ func TestReadVersionFromMod(t *testing.T) {
 				UseRealIsAfter:  true,
 			}
 			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, clock)
-			goVersion, err := fileHelper.ReadVersionFromMod()
+			goVersions, toolchains, err := fileHelper.ReadVersionFromMod(tc.path)
 
 			if tc.expectedError == nil && err != nil {
 				t.Errorf("error should be nil, instead got %q", err.Error())
 				return
 			}
 
-			if tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
-				t.Errorf("error should be %q, instead got %q", tc.expectedError.Error(), err.Error())
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(goVersions, tc.expectedVersions) {
+				t.Errorf("Wrong versions received, got=%s", cmp.Diff(goVersions, tc.expectedVersions))
+				return
+			}
+
+			if !cmp.Equal(toolchains, tc.expectedToolchains) {
+				t.Errorf("Wrong toolchains received, got=%s", cmp.Diff(toolchains, tc.expectedToolchains))
+				return
+			}
+		})
+	}
+}
+
+func TestFindModFile(t *testing.T) {
+	testCases := []struct {
+		testTitle     string
+		workingDir    string
+		getwdError    error
+		files         map[string][]byte
+		expectedPath  string
+		expectedError error
+	}{
+		{
+			testTitle:  "should return the go.mod file of the current directory",
+			workingDir: "/repo",
+			files: map[string][]byte{
+				"/repo/go.mod": nil,
+			},
+			expectedPath:  "/repo/go.mod",
+			expectedError: nil,
+		},
+		{
+			testTitle:  "should return the go.mod file of a parent directory",
+			workingDir: "/repo/internal/pkg",
+			files: map[string][]byte{
+				"/repo/go.mod": nil,
+			},
+			expectedPath:  "/repo/go.mod",
+			expectedError: nil,
+		},
+		{
+			testTitle:  "should return the closest go.mod file",
+			workingDir: "/repo/tools/lint",
+			files: map[string][]byte{
+				"/repo/go.mod":       nil,
+				"/repo/tools/go.mod": nil,
+			},
+			expectedPath:  "/repo/tools/go.mod",
+			expectedError: nil,
+		},
+		{
+			testTitle:  "should prefer the go.work file over a closer go.mod file",
+			workingDir: "/repo/api/handlers",
+			files: map[string][]byte{
+				"/repo/go.work":    nil,
+				"/repo/api/go.mod": nil,
+			},
+			expectedPath:  "/repo/go.work",
+			expectedError: nil,
+		},
+		{
+			testTitle:  "should stop at the root of the repository",
+			workingDir: "/home/user/repo/cmd",
+			files: map[string][]byte{
+				"/home/user/repo/.git": nil,
+				"/home/user/go.work":   nil,
+				"/home/user/go.mod":    nil,
+			},
+			expectedPath:  "",
+			expectedError: errors.New("go.mod or go.work file not found in the current directory or any of its parent directories"),
+		},
+		{
+			testTitle:     "should return an error when there is no file until the root of the filesystem",
+			workingDir:    "/repo/cmd",
+			files:         map[string][]byte{},
+			expectedPath:  "",
+			expectedError: errors.New("go.mod or go.work file not found in the current directory or any of its parent directories"),
+		},
+		{
+			testTitle:     "should return an error when the current directory can't be found",
+			getwdError:    errors.New("getwd: no such file or directory"),
+			files:         map[string][]byte{},
+			expectedPath:  "",
+			expectedError: errors.New("getwd: no such file or directory"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:    "/tmp",
+				WorkingDir: tc.workingDir,
+				GetwdError: tc.getwdError,
+				Files:      tc.files,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			path, err := fileHelper.FindModFile()
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
 				return
 			}
 
-			if goVersion != tc.expectedVersion {
-				t.Errorf("the version should be %q, instead got %q", tc.expectedVersion, goVersion)
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
 				return
 			}
+
+			if path != tc.expectedPath {
+				t.Errorf("path should be %q, instead got %q", tc.expectedPath, path)
+			}
 		})
 	}
 }
+
+func TestFindVersionFile(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		workingDir      string
+		files           map[string][]byte
+		expectedPath    string
+		expectedVersion string
+	}{
+		{
+			testTitle:  "should return the version of the .go-version file",
+			workingDir: "/repo",
+			files: map[string][]byte{
+				"/repo/.go-version": []byte("# comment\n\n1.21.5\n"),
+			},
+			expectedPath:    "/repo/.go-version",
+			expectedVersion: "1.21.5",
+		},
+		{
+			testTitle:  "should return the version of the .tool-versions file",
+			workingDir: "/repo/cmd",
+			files: map[string][]byte{
+				"/repo/.tool-versions": []byte("nodejs 20.1.0\ngolang 1.21.5 1.21.4 # fallback\n"),
+			},
+			expectedPath:    "/repo/.tool-versions",
+			expectedVersion: "1.21.5",
+		},
+		{
+			testTitle:  "should prefer the .go-version file over the .tool-versions file of the same directory",
+			workingDir: "/repo",
+			files: map[string][]byte{
+				"/repo/.go-version":    []byte("1.20"),
+				"/repo/.tool-versions": []byte("golang 1.21.5"),
+			},
+			expectedPath:    "/repo/.go-version",
+			expectedVersion: "1.20",
+		},
+		{
+			testTitle:  "should return the closest file",
+			workingDir: "/repo/cmd",
+			files: map[string][]byte{
+				"/repo/.go-version":        []byte("1.20"),
+				"/repo/cmd/.tool-versions": []byte("golang 1.21.5"),
+			},
+			expectedPath:    "/repo/cmd/.tool-versions",
+			expectedVersion: "1.21.5",
+		},
+		{
+			testTitle:  "should skip the .tool-versions file without a Go version",
+			workingDir: "/repo/cmd",
+			files: map[string][]byte{
+				"/repo/.go-version":        []byte("1.20"),
+				"/repo/cmd/.tool-versions": []byte("nodejs 20.1.0"),
+			},
+			expectedPath:    "/repo/.go-version",
+			expectedVersion: "1.20",
+		},
+		{
+			testTitle:  "should stop at the root of the repository",
+			workingDir: "/home/user/repo",
+			files: map[string][]byte{
+				"/home/user/repo/.git":   nil,
+				"/home/user/.go-version": []byte("1.20"),
+			},
+			expectedPath:    "",
+			expectedVersion: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:    "/tmp",
+				WorkingDir: tc.workingDir,
+				Files:      tc.files,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			path, version, err := fileHelper.FindVersionFile()
+
+			if err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if path != tc.expectedPath {
+				t.Errorf("path should be %q, instead got %q", tc.expectedPath, path)
+			}
+
+			if version != tc.expectedVersion {
+				t.Errorf("version should be %q, instead got %q", tc.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestGetGlobalVersion(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		files           map[string][]byte
+		expectedVersion string
+	}{
+		{
+			testTitle: "should return the global version",
+			files: map[string][]byte{
+				"/tmp/.gvs/.go-version": []byte("1.21.5\n"),
+			},
+			expectedVersion: "1.21.5",
+		},
+		{
+			testTitle:       "should return an empty version when the file does not exist",
+			files:           map[string][]byte{},
+			expectedVersion: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir: "/tmp",
+				Files:   tc.files,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			path, version, err := fileHelper.GetGlobalVersion()
+
+			if err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if path != "/tmp/.gvs/.go-version" {
+				t.Errorf("path should be %q, instead got %q", "/tmp/.gvs/.go-version", path)
+			}
+
+			if version != tc.expectedVersion {
+				t.Errorf("version should be %q, instead got %q", tc.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestWriteLocalVersion(t *testing.T) {
+	testCases := []struct {
+		testTitle        string
+		writeToFileError error
+		expectedPath     string
+	}{
+		{
+			testTitle:        "should write the version to the .go-version file of the current directory",
+			writeToFileError: nil,
+			expectedPath:     "/repo/.go-version",
+		},
+		{
+			testTitle:        "should return an error when the file can't be written",
+			writeToFileError: errors.New("an error occurred while writing to the file"),
+			expectedPath:     "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:        "/tmp",
+				WorkingDir:     "/repo",
+				WriteFileError: tc.writeToFileError,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			path, err := fileHelper.WriteLocalVersion("1.21.5")
+
+			if tc.writeToFileError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+			}
+
+			if tc.writeToFileError != nil && (err == nil || err.Error() != tc.writeToFileError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.writeToFileError.Error(), err)
+			}
+
+			if path != tc.expectedPath {
+				t.Errorf("path should be %q, instead got %q", tc.expectedPath, path)
+			}
+		})
+	}
+}
+
+func TestWriteGlobalVersion(t *testing.T) {
+	fs := testutils.FakeFileSystem{HomeDir: "/tmp"}
+
+	fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+	path, err := fileHelper.WriteGlobalVersion("1.21.5")
+
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+	}
+
+	if path != "/tmp/.gvs/.go-version" {
+		t.Errorf("path should be %q, instead got %q", "/tmp/.gvs/.go-version", path)
+	}
+}
+
+func TestGetInstalledVersions(t *testing.T) {
+	testCases := []struct {
+		testTitle        string
+		readDirError     error
+		readDirResponse  []testutils.FakeDirEntry
+		expectedError    error
+		expectedVersions []string
+	}{
+		{
+			testTitle:        "should fail when ReadDir returns an error back",
+			readDirError:     errors.New("an error occurred while reading the directory path"),
+			readDirResponse:  getEmptyDirEntries(),
+			expectedError:    errors.New("an error occurred while reading the directory path"),
+			expectedVersions: nil,
+		},
+		{
+			testTitle: "should return only the version directories",
+			readDirResponse: []testutils.FakeDirEntry{
+				{DirEntryName: "go1.21.5", DirEntryIsDir: true},
+				{DirEntryName: "CURRENT", DirEntryIsDir: false},
+				{DirEntryName: "go1.20.10", DirEntryIsDir: true},
+				{DirEntryName: "tmp", DirEntryIsDir: true},
+			},
+			expectedError:    nil,
+			expectedVersions: []string{"go1.21.5", "go1.20.10"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:             "/tmp",
+				ReadDirError:        tc.readDirError,
+				ReadDirMockResponse: getDirEntries(tc.readDirResponse),
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			versions, err := fileHelper.GetInstalledVersions()
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(versions, tc.expectedVersions) {
+				t.Errorf("Wrong versions received, got=%s", cmp.Diff(versions, tc.expectedVersions))
+			}
+		})
+	}
+}
+
+func TestGetVersionDirectory(t *testing.T) {
+	fs := testutils.FakeFileSystem{HomeDir: "/tmp"}
+
+	fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+	dir := fileHelper.GetVersionDirectory("go1.21.5")
+
+	if dir != "/tmp/.gvs/.go.versions/go1.21.5" {
+		t.Errorf("directory should be %q, instead got %q", "/tmp/.gvs/.go.versions/go1.21.5", dir)
+	}
+}
+
+func TestGetGorootBinary(t *testing.T) {
+	testCases := []struct {
+		testTitle      string
+		name           string
+		expectedBinary string
+	}{
+		{
+			testTitle:      "should return the path of the binary",
+			name:           "go",
+			expectedBinary: "/tmp/.gvs/.go.versions/go1.21.5/bin/go",
+		},
+		{
+			testTitle:      "should return an empty string when the binary doesn't exist",
+			name:           "golangci-lint",
+			expectedBinary: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{HomeDir: "/tmp", Files: map[string][]byte{"/tmp/.gvs/.go.versions/go1.21.5/bin/go": {}}}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			binary := fileHelper.GetGorootBinary("/tmp/.gvs/.go.versions/go1.21.5", tc.name)
+
+			if binary != tc.expectedBinary {
+				t.Errorf("binary should be %q, instead got %q", tc.expectedBinary, binary)
+			}
+		})
+	}
+}
+
+func TestCreateShims(t *testing.T) {
+	testCases := []struct {
+		testTitle      string
+		autoInstall    bool
+		writeFileError error
+		expectedGoShim string
+		expectedError  error
+	}{
+		{
+			testTitle:      "should replace the symlinks with the shims",
+			autoInstall:    false,
+			expectedGoShim: "#!/bin/sh\n# gvs shim, run 'gvs shims disable' to restore the symlinks.\nexec '/usr/local/bin/gvs' shim-exec go -- \"$@\"\n",
+			expectedError:  nil,
+		},
+		{
+			testTitle:      "should create the shims that install the version",
+			autoInstall:    true,
+			expectedGoShim: "#!/bin/sh\n# gvs shim, run 'gvs shims disable' to restore the symlinks.\nexec '/usr/local/bin/gvs' shim-exec --install go -- \"$@\"\n",
+			expectedError:  nil,
+		},
+		{
+			testTitle:      "should return an error when the shims can't be written",
+			writeFileError: errors.New("an error occurred while writing to the file"),
+			expectedError:  errors.New("an error occurred while writing to the file"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:        "/tmp",
+				WriteFileError: tc.writeFileError,
+				Files: map[string][]byte{
+					"/tmp/bin/go": []byte("symlink"),
+				},
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			err := fileHelper.CreateShims("/usr/local/bin/gvs", tc.autoInstall)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil {
+				if err == nil || err.Error() != tc.expectedError.Error() {
+					t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				}
+
+				if fileHelper.AreShimsEnabled() {
+					t.Error("shims should not be enabled")
+				}
+
+				return
+			}
+
+			if string(fs.Files["/tmp/bin/go"]) != tc.expectedGoShim {
+				t.Errorf("go shim should be %q, instead got %q", tc.expectedGoShim, string(fs.Files["/tmp/bin/go"]))
+			}
+
+			if _, ok := fs.Files["/tmp/bin/gofmt"]; !ok {
+				t.Error("gofmt shim should be created")
+			}
+
+			if !fileHelper.AreShimsEnabled() {
+				t.Error("shims should be enabled")
+			}
+		})
+	}
+}
+
+func TestRemoveShims(t *testing.T) {
+	fs := testutils.FakeFileSystem{
+		HomeDir: "/tmp",
+		Files: map[string][]byte{
+			"/tmp/bin/go":     []byte("#!/bin/sh\n# gvs shim, run 'gvs shims disable' to restore the symlinks.\nexec gvs shim-exec go -- \"$@\"\n"),
+			"/tmp/bin/gofmt":  []byte("not a shim"),
+			"/tmp/.gvs/SHIMS": {},
+		},
+	}
+
+	fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+	err := fileHelper.RemoveShims()
+
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	expectedFiles := map[string][]byte{"/tmp/bin/gofmt": []byte("not a shim")}
+	if !cmp.Equal(fs.Files, expectedFiles) {
+		t.Errorf("Wrong files left, got=%s", cmp.Diff(fs.Files, expectedFiles))
+	}
+
+	// the shims are already removed
+	if err := fileHelper.RemoveShims(); err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+	}
+}
+
+func TestGetAPIFeatures(t *testing.T) {
+	testCases := []struct {
+		testTitle        string
+		readDirError     error
+		readDirResponse  []testutils.FakeDirEntry
+		files            map[string][]byte
+		expectedError    error
+		expectedFeatures []string
+	}{
+		{
+			testTitle:        "should fail when ReadDir returns an error back",
+			readDirError:     errors.New("an error occurred while reading the directory path"),
+			readDirResponse:  getEmptyDirEntries(),
+			files:            map[string][]byte{},
+			expectedError:    errors.New("an error occurred while reading the directory path"),
+			expectedFeatures: nil,
+		},
+		{
+			testTitle: "should fail when an API file can't be read",
+			readDirResponse: []testutils.FakeDirEntry{
+				{DirEntryName: "go1.txt"},
+			},
+			files:            map[string][]byte{},
+			expectedError:    errors.New("file does not exist"),
+			expectedFeatures: nil,
+		},
+		{
+			testTitle: "should return the features of the API files without the issue numbers",
+			readDirResponse: []testutils.FakeDirEntry{
+				{DirEntryName: "except.txt"},
+				{DirEntryName: "go1.txt"},
+				{DirEntryName: "go1.21.txt"},
+				{DirEntryName: "next", DirEntryIsDir: true},
+			},
+			files: map[string][]byte{
+				"/tmp/.gvs/.go.versions/go1.21.5/api/except.txt": []byte("pkg os, const O_RDONLY = 0\n"),
+				"/tmp/.gvs/.go.versions/go1.21.5/api/go1.txt":    []byte("pkg bytes, func Compare([]uint8, []uint8) int\n"),
+				"/tmp/.gvs/.go.versions/go1.21.5/api/go1.21.txt": []byte("# a comment\npkg slices, func Max[$0 interface{ ~[]$1 }, $1 cmp.Ordered]($0) $1 #60091\n\npkg log/slog, func Info(string, ...interface{})\n"),
+			},
+			expectedError: nil,
+			expectedFeatures: []string{
+				"pkg bytes, func Compare([]uint8, []uint8) int",
+				"pkg slices, func Max[$0 interface{ ~[]$1 }, $1 cmp.Ordered]($0) $1",
+				"pkg log/slog, func Info(string, ...interface{})",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:             "/tmp",
+				ReadDirError:        tc.readDirError,
+				ReadDirMockResponse: getDirEntries(tc.readDirResponse),
+				Files:               tc.files,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			features, err := fileHelper.GetAPIFeatures("go1.21.5")
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(features, tc.expectedFeatures) {
+				t.Errorf("Wrong features received, got=%s", cmp.Diff(features, tc.expectedFeatures))
+			}
+		})
+	}
+}
+
+func TestUpdateVersionUsage(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		files           map[string][]byte
+		renameError     error
+		expectedError   error
+		expectedContent string
+	}{
+		{
+			testTitle:       "should create the usage file",
+			files:           map[string][]byte{},
+			expectedContent: "{\n  \"go1.21.5\": \"0001-01-01T00:00:00Z\"\n}",
+		},
+		{
+			testTitle: "should keep the usage of the other versions",
+			files: map[string][]byte{
+				"/tmp/.gvs/.go.versions/USAGE": []byte(`{"go1.20.1": "2023-12-01T10:00:00Z"}`),
+			},
+			expectedContent: "{\n  \"go1.20.1\": \"2023-12-01T10:00:00Z\",\n  \"go1.21.5\": \"0001-01-01T00:00:00Z\"\n}",
+		},
+		{
+			testTitle: "should replace the usage file when it's not valid",
+			files: map[string][]byte{
+				"/tmp/.gvs/.go.versions/USAGE": []byte(`{"go1.20.1": "2023-12-01T1`),
+			},
+			expectedContent: "{\n  \"go1.21.5\": \"0001-01-01T00:00:00Z\"\n}",
+		},
+		{
+			testTitle: "should keep the usage file when it can't be replaced",
+			files: map[string][]byte{
+				"/tmp/.gvs/.go.versions/USAGE": []byte(`{"go1.20.1": "2023-12-01T10:00:00Z"}`),
+			},
+			renameError:     errors.New("an error occurred while renaming the file"),
+			expectedError:   errors.New("an error occurred while renaming the file"),
+			expectedContent: `{"go1.20.1": "2023-12-01T10:00:00Z"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:     "/tmp",
+				Files:       tc.files,
+				RenameError: tc.renameError,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			err := fileHelper.UpdateVersionUsage("go1.21.5")
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			content := string(tc.files["/tmp/.gvs/.go.versions/USAGE"])
+			if content != tc.expectedContent {
+				t.Errorf("Wrong content received, got=%s", cmp.Diff(content, tc.expectedContent))
+			}
+
+			if len(tc.files) != 1 {
+				t.Errorf("the temporary file should be removed, instead got the files %v", tc.files)
+			}
+		})
+	}
+}
+
+func TestIsVersionUsedWithin(t *testing.T) {
+	testCases := []struct {
+		testTitle     string
+		files         map[string][]byte
+		diffInHours   float64
+		expectedValue bool
+	}{
+		{
+			testTitle: "should return true when the version is used in the given days",
+			files: map[string][]byte{
+				"/tmp/.gvs/.go.versions/USAGE": []byte(`{"go1.21.5": "2023-12-01T10:00:00Z"}`),
+			},
+			diffInHours:   10,
+			expectedValue: true,
+		},
+		{
+			testTitle: "should return false when the version is not used in the given days",
+			files: map[string][]byte{
+				"/tmp/.gvs/.go.versions/USAGE": []byte(`{"go1.21.5": "2023-12-01T10:00:00Z"}`),
+			},
+			diffInHours:   48,
+			expectedValue: false,
+		},
+		{
+			testTitle: "should use the time the version was installed when its usage is not stored",
+			files: map[string][]byte{
+				"/tmp/.gvs/.go.versions/go1.21.5": {},
+			},
+			diffInHours:   10,
+			expectedValue: true,
+		},
+		{
+			testTitle:     "should return false when the version is not installed",
+			files:         map[string][]byte{},
+			diffInHours:   10,
+			expectedValue: false,
+		},
+		{
+			testTitle: "should use the time the version was installed when the usage file is not valid",
+			files: map[string][]byte{
+				"/tmp/.gvs/.go.versions/USAGE":    []byte(`not json`),
+				"/tmp/.gvs/.go.versions/go1.21.5": {},
+			},
+			diffInHours:   10,
+			expectedValue: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir: "/tmp",
+				Files:   tc.files,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{GetDiffInHoursFromNowRes: tc.diffInHours})
+
+			if used := fileHelper.IsVersionUsedWithin("go1.21.5", 1); used != tc.expectedValue {
+				t.Errorf("used should be %t, instead got %t", tc.expectedValue, used)
+			}
+		})
+	}
+}
+
+func TestGetVersionSize(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		readDirError    error
+		readDirResponse []testutils.FakeDirEntry
+		expectedError   error
+		expectedSize    int64
+	}{
+		{
+			testTitle:       "should fail when ReadDir returns an error back",
+			readDirError:    errors.New("an error occurred while reading the directory path"),
+			readDirResponse: getEmptyDirEntries(),
+			expectedError:   errors.New("an error occurred while reading the directory path"),
+		},
+		{
+			testTitle: "should fail when the file info can't be read",
+			readDirResponse: []testutils.FakeDirEntry{
+				{DirEntryName: "go", DirEntryInfoError: errors.New("an error occurred while reading the file info")},
+			},
+			expectedError: errors.New("an error occurred while reading the file info"),
+		},
+		{
+			testTitle: "should return the size of the files",
+			readDirResponse: []testutils.FakeDirEntry{
+				{DirEntryName: "go", DirEntryInfo: testutils.FakeFileInfo{FileSize: 1000}},
+				{DirEntryName: "gofmt", DirEntryInfo: testutils.FakeFileInfo{FileSize: 500}},
+			},
+			expectedSize: 1500,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir:             "/tmp",
+				ReadDirError:        tc.readDirError,
+				ReadDirMockResponse: getDirEntries(tc.readDirResponse),
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			size, err := fileHelper.GetVersionSize("go1.21.5")
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if size != tc.expectedSize {
+				t.Errorf("size should be %d, instead got %d", tc.expectedSize, size)
+			}
+		})
+	}
+}
+
+func TestGetPrunePolicy(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		files           map[string][]byte
+		expectedContent []byte
+	}{
+		{
+			testTitle:       "should return nil when there is no policy",
+			files:           map[string][]byte{},
+			expectedContent: nil,
+		},
+		{
+			testTitle: "should return the content of the policy",
+			files: map[string][]byte{
+				"/tmp/.gvs/PRUNE": []byte(`{"keep_newest": 3}`),
+			},
+			expectedContent: []byte(`{"keep_newest": 3}`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fs := testutils.FakeFileSystem{
+				HomeDir: "/tmp",
+				Files:   tc.files,
+			}
+
+			fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+			content, err := fileHelper.GetPrunePolicy()
+
+			if err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if !cmp.Equal(content, tc.expectedContent) {
+				t.Errorf("Wrong content received, got=%s", cmp.Diff(content, tc.expectedContent))
+			}
+		})
+	}
+}
+
+func TestGetPrunePolicyError(t *testing.T) {
+	expectedError := errors.New("an error occurred while reading the file")
+
+	fs := testutils.FakeFileSystem{
+		HomeDir:       "/tmp",
+		ReadFileError: expectedError,
+	}
+
+	fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+	_, err := fileHelper.GetPrunePolicy()
+
+	if err == nil || err.Error() != expectedError.Error() {
+		t.Errorf("error should be %q, instead got %v", expectedError.Error(), err)
+	}
+}
+
+func TestStorePrunePolicy(t *testing.T) {
+	files := map[string][]byte{}
+	fs := testutils.FakeFileSystem{
+		HomeDir: "/tmp",
+		Files:   files,
+	}
+
+	fileHelper := createFileHelper(&testutils.FakeStdout{}, nil, fs, testutils.FakeUnzipper{}, testutils.FakeClock{})
+	if err := fileHelper.StorePrunePolicy([]byte(`{"auto": true}`)); err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	if content := string(files["/tmp/.gvs/PRUNE"]); content != `{"auto": true}` {
+		t.Errorf("content should be %q, instead got %q", `{"auto": true}`, content)
+	}
+}
@@ -32,6 +32,8 @@ type FS interface {
 	Copy(dst io.Writer, src io.Reader) (written int64, err error)
 	// Symlink creates newname as a symbolic link to oldname.
 	Symlink(oldname string, newname string) error
+	// Readlink returns the destination of the named symbolic link.
+	Readlink(name string) (string, error)
 
 	// WriteFile writes data to the named file, creating it if necessary.
 	WriteFile(name string, data []byte, perm ioFS.FileMode) error
@@ -53,6 +55,8 @@ type FS interface {
 
 	// GetHomeDirectory return back the home directory for the current user.
 	GetHomeDirectory() string
+	// Getwd returns a rooted path name corresponding to the current directory.
+	Getwd() (dir string, err error)
 }
 
 // FileSystem is the struct that implements the FS interface
@@ -128,6 +132,13 @@ func (FileSystem) Symlink(oldname string, newname string) error {
 	return os.Symlink(oldname, newname)
 }
 
+// Readlink returns the destination of the named symbolic link.
+//
+// It is a wrapper for the os.Readlink function.
+func (FileSystem) Readlink(name string) (string, error) {
+	return os.Readlink(name)
+}
+
 // WriteFile writes data to the named file, creating it if necessary.
 //
 // It is a wrapper for the os.WriteFile function.
@@ -199,3 +210,10 @@ func (FileSystem) GetHomeDirectory() string {
 
 	return dirname
 }
+
+// Getwd returns a rooted path name corresponding to the current directory.
+//
+// It is a wrapper for the os.Getwd function.
+func (FileSystem) Getwd() (dir string, err error) {
+	return os.Getwd()
+}
@@ -25,8 +25,44 @@ var (
 	// currentVersionFileName contains the file name where the currect (used) Go version is stored
 	currentVersionFileName = "CURRENT"
 
+	// previousVersionFileName contains the file name where the Go version that was used before the current one is stored
+	previousVersionFileName = "PREVIOUS"
+
+	// aliasesFileName contains the file name where the user-defined version aliases are stored.
+	aliasesFileName = "ALIASES"
+
+	// shimsFileName contains the file name that indicates that the shims are used instead of the symlinks.
+	shimsFileName = "SHIMS"
+
+	// usageFileName contains the file name where the time each Go version was last used is stored
+	usageFileName = "USAGE"
+
+	// prunePolicyFileName contains the file name where the retention policy of `gvs prune` is stored
+	prunePolicyFileName = "PRUNE"
+
+	// shimHeader is the comment that is added on the shims, so they can be told apart from other files.
+	shimHeader = "# gvs shim"
+
 	// logFile contains the file name where the logs are stored for debugging.
 	logFile = "gvs.log"
+
+	// goModFileName contains the file name of the module files.
+	goModFileName = "go.mod"
+
+	// goWorkFileName contains the file name of the workspace files.
+	goWorkFileName = "go.work"
+
+	// goVersionFileName contains the file name of the version files (also used by goenv).
+	goVersionFileName = ".go-version"
+
+	// toolVersionsFileName contains the file name of the asdf version files.
+	toolVersionsFileName = ".tool-versions"
+
+	// shimTools contains the tools that are replaced from the shims.
+	shimTools = []string{"go", "gofmt"}
+
+	// vcsDirs contains the directories that indicate the root of a repository.
+	vcsDirs = []string{".git", ".hg", ".svn", ".bzr"}
 )
 
 // getAppDir returns the path for the `.gvs/` directory.
@@ -57,6 +93,48 @@ func getCurrentVersionFile(fs FS) string {
 	return fmt.Sprintf("%s/%s", getVersionsDir(fs), currentVersionFileName)
 }
 
+// getPreviousVersionFile returns the path for the `PREVIOUS` file.
+//
+// It is using the FS interface to get the $HOME directory, which is used as the starting point.
+func getPreviousVersionFile(fs FS) string {
+	return fmt.Sprintf("%s/%s", getVersionsDir(fs), previousVersionFileName)
+}
+
+// getAliasesFile returns the path for the `ALIASES` file.
+//
+// It is using the FS interface to get the $HOME directory, which is used as the starting point.
+func getAliasesFile(fs FS) string {
+	return fmt.Sprintf("%s/%s", getVersionsDir(fs), aliasesFileName)
+}
+
+// getUsageFile returns the path for the `USAGE` file.
+//
+// It is using the FS interface to get the $HOME directory, which is used as the starting point.
+func getUsageFile(fs FS) string {
+	return fmt.Sprintf("%s/%s", getVersionsDir(fs), usageFileName)
+}
+
+// getPrunePolicyFile returns the path for the `PRUNE` file.
+//
+// It is using the FS interface to get the $HOME directory, which is used as the starting point.
+func getPrunePolicyFile(fs FS) string {
+	return fmt.Sprintf("%s/%s", getAppDir(fs), prunePolicyFileName)
+}
+
+// getGlobalVersionFile returns the path for the `.go-version` file that contains the global default version.
+//
+// It is using the FS interface to get the $HOME directory, which is used as the starting point.
+func getGlobalVersionFile(fs FS) string {
+	return fmt.Sprintf("%s/%s", getAppDir(fs), goVersionFileName)
+}
+
+// getShimsFile returns the path for the `SHIMS` file.
+//
+// It is using the FS interface to get the $HOME directory, which is used as the starting point.
+func getShimsFile(fs FS) string {
+	return fmt.Sprintf("%s/%s", getAppDir(fs), shimsFileName)
+}
+
 // getBinDir returns the path for the `bin/` directory.
 //
 // It is using the FS interface to get the $HOME directory, which is used as the starting point.
@@ -1,8 +1,10 @@
 package flags
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 
 	terminalColors "github.com/fatih/color"
 )
@@ -35,44 +37,124 @@ func (f Flag) getHelpName() string {
 
 	if f.shortName != "" {
 		flagName += fmt.Sprintf(", -%s", f.shortName)
-	}
 
-	if f.acceptsVale {
-		flagName += "=value"
+		if f.acceptsVale {
+			flagName += "=value"
+		}
 	}
 
 	return flagName
 }
 
+// Command contains the information for a subcommand of the CLI (e.g. `gvs install 1.21`).
+type Command struct {
+	// The name that is used to invoke the command.
+	name string
+
+	// The positional arguments the command accepts, as they are printed on the help message (e.g. <version>).
+	args string
+
+	// A short description of what the command does.
+	description string
+
+	// flags contains the flags that are available only for this command.
+	flags *FlagSet
+
+	// run is the function that is called with the positional arguments
+	// that are left after parsing the command flags.
+	run func(args []string) error
+}
+
 // FlagSet is the struct that will be used to set up the flags for the CLI.
 type FlagSet struct {
 	// An array of the flags that are available.
 	// flags is used as a store to easy iterate on the flags.
 	flags []Flag
+
+	// An array of the subcommands that are available.
+	commands []*Command
+
+	// set is the underlying flag set the flags are registered to.
+	// When set is nil, the flags are registered to the global flag.CommandLine.
+	set *flag.FlagSet
+}
+
+// flagSet returns the underlying flag set the flags are registered to.
+func (s *FlagSet) flagSet() *flag.FlagSet {
+	if s.set == nil {
+		return flag.CommandLine
+	}
+
+	return s.set
 }
 
 // FlagBool defines a bool flag with specified name, short name (single character), default value, and usage string.
 // The argument p points to a bool variable in which to store the value of the flag.
+// If the flag does not have a short name, shortName should be 0.
 // FlagBool also appends the flag to the FlagSet array.
 func (s *FlagSet) FlagBool(p *bool, name string, shortName rune, value bool, usage string) {
-	s.flags = append(s.flags, Flag{name: name, shortName: string(shortName), acceptsVale: false})
-	flag.BoolVar(p, name, value, usage)
+	f := Flag{name: name, acceptsVale: false}
+	s.flagSet().BoolVar(p, name, value, usage)
 
-	if string(shortName) != "" {
-		flag.BoolVar(p, string(shortName), value, usage)
+	if shortName != 0 {
+		f.shortName = string(shortName)
+		s.flagSet().BoolVar(p, f.shortName, value, usage)
 	}
+
+	s.flags = append(s.flags, f)
 }
 
 // StringVar defines a string flag with specified name, short name (single character), default value, and usage string.
 // The argument p points to a string variable in which to store the value of the flag.
+// If the flag does not have a short name, shortName should be 0.
 // FlagBool also appends the flag to the FlagSet array.
 func (s *FlagSet) FlagStr(p *string, name string, shortName rune, value string, usage string) {
-	s.flags = append(s.flags, Flag{name: name, shortName: string(shortName), acceptsVale: true})
-	flag.StringVar(p, name, value, usage)
+	f := Flag{name: name, acceptsVale: true}
+	s.flagSet().StringVar(p, name, value, usage)
+
+	if shortName != 0 {
+		f.shortName = string(shortName)
+		s.flagSet().StringVar(p, f.shortName, value, usage)
+	}
+
+	s.flags = append(s.flags, f)
+}
+
+// FlagInt defines an int flag with specified name, short name (single character), default value, and usage string.
+// The argument p points to an int variable in which to store the value of the flag.
+// If the flag does not have a short name, shortName should be 0.
+// FlagInt also appends the flag to the FlagSet array.
+func (s *FlagSet) FlagInt(p *int, name string, shortName rune, value int, usage string) {
+	f := Flag{name: name, acceptsVale: true}
+	s.flagSet().IntVar(p, name, value, usage)
+
+	if shortName != 0 {
+		f.shortName = string(shortName)
+		s.flagSet().IntVar(p, f.shortName, value, usage)
+	}
+
+	s.flags = append(s.flags, f)
+}
 
-	if string(shortName) != "" {
-		flag.StringVar(p, string(shortName), value, usage)
+// Command defines a subcommand with the specified name, positional arguments description, description and
+// the function that will be called when the command is invoked.
+//
+// Command returns back the FlagSet of the subcommand, so the command specific flags can be registered on it.
+func (s *FlagSet) Command(name string, args string, description string, run func(args []string) error) *FlagSet {
+	cmd := &Command{
+		name:        name,
+		args:        args,
+		description: description,
+		flags:       &FlagSet{set: flag.NewFlagSet(name, flag.ContinueOnError)},
+		run:         run,
 	}
+
+	// the errors are returned back from Dispatch and the help message is printed from printCommandUsage
+	cmd.flags.set.SetOutput(io.Discard)
+	cmd.flags.set.Usage = func() {}
+	s.commands = append(s.commands, cmd)
+
+	return cmd.flags
 }
 
 // printSynopsis returns back all the available flags without any description.
@@ -83,13 +165,24 @@ func (s *FlagSet) printSynopsis() {
 		msg += fmt.Sprintf("   [%s]\n", flag.getHelpName())
 	}
 
+	if len(s.commands) > 0 {
+		msg += "   <command> [command flags] [arguments]\n"
+	}
+
 	fmt.Printf("%s\n", msg)
 }
 
+// printCommands returns back all the available commands with a description.
+func (s *FlagSet) printCommands() {
+	for _, cmd := range s.commands {
+		fmt.Printf("  %s\n\t%s\n", cmd.name, cmd.description)
+	}
+}
+
 // printFlags returns back all the available flags wiath a description.
 // All the flags are iterated from FlagSet array that contains the flags.
 func (s *FlagSet) printFlags() {
-	flagSet := flag.CommandLine
+	flagSet := s.flagSet()
 
 	for _, flag := range s.flags {
 		flagInfo := flagSet.Lookup(flag.name)
@@ -97,6 +190,75 @@ func (s *FlagSet) printFlags() {
 	}
 }
 
+// printCommandUsage prints the help message for the given command.
+func (s *FlagSet) printCommandUsage(cmd *Command) {
+	bold := terminalColors.New().Add(terminalColors.Bold)
+
+	fmt.Println()
+	bold.Println("NAME")
+	fmt.Printf("  gvs %s - %s\n\n", cmd.name, cmd.description)
+
+	bold.Println("SYNOPSIS")
+	synopsis := fmt.Sprintf("  gvs %s", cmd.name)
+	if len(cmd.flags.flags) > 0 {
+		synopsis += " [flags]"
+	}
+	if cmd.args != "" {
+		synopsis += " " + cmd.args
+	}
+	fmt.Printf("%s\n\n", synopsis)
+
+	if len(cmd.flags.flags) > 0 {
+		bold.Println("FLAGS")
+		cmd.flags.printFlags()
+	}
+}
+
+// findCommand returns the command with the given name, or nil if the command does not exist.
+func (s *FlagSet) findCommand(name string) *Command {
+	for _, cmd := range s.commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+
+	return nil
+}
+
+// parseInterspersed parses the flags even if they are passed after the positional arguments
+// (e.g. `gvs install 1.21 -a` is parsed the same as `gvs install -a 1.21`) and returns back the positional arguments.
+//
+// Everything after the `--` terminator is not parsed, and is returned back as is, including
+// the terminator itself, so commands that execute other programs can receive their arguments unchanged.
+func (s *FlagSet) parseInterspersed(args []string) ([]string, error) {
+	var positional []string
+	var rest []string
+
+	for i, arg := range args {
+		if arg == "--" {
+			rest = args[i:]
+			args = args[:i]
+			break
+		}
+	}
+
+	for {
+		if err := s.set.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = s.set.Args()
+		if len(args) == 0 {
+			break
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	return append(positional, rest...), nil
+}
+
 // Parse is preparing the help command and parses the flags.
 func (s *FlagSet) Parse() {
 	flag.Usage = func() {
@@ -114,10 +276,17 @@ func (s *FlagSet) Parse() {
 		bold.Println("SYNOPSIS")
 		s.printSynopsis()
 
+		if len(s.commands) > 0 {
+			bold.Println("COMMANDS")
+			s.printCommands()
+			fmt.Println()
+		}
+
 		bold.Println("FLAGS")
 		s.printFlags()
 
 		fmt.Println()
+		fmt.Printf("Run %q for more information about a command.\n\n", "gvs <command> --help")
 		fmt.Printf("Before start using the %s CLI, make sure to delete all the existing go versions\n", gvsMessage)
 		fmt.Printf("and append to your profile file the export: %q.\n", "export PATH=$PATH:$HOME/bin")
 		fmt.Printf("The profile file could be one of: (%s)\n", "~/.bash_profile, ~/.zshrc, ~/.profile, or ~/.bashrc")
@@ -125,3 +294,71 @@ func (s *FlagSet) Parse() {
 
 	flag.Parse()
 }
+
+// IsSet returns true if the flag with the given name was passed, either with its name or with its short name.
+func (s *FlagSet) IsSet(name string) bool {
+	names := map[string]bool{name: true}
+	for _, f := range s.flags {
+		if f.name == name && f.shortName != "" {
+			names[f.shortName] = true
+		}
+	}
+
+	isSet := false
+	s.flagSet().Visit(func(f *flag.Flag) {
+		if names[f.Name] {
+			isSet = true
+		}
+	})
+
+	return isSet
+}
+
+// Args returns the non-flag arguments that are left after Parse is called.
+// The first argument (if any) is the name of the subcommand.
+func (s *FlagSet) Args() []string {
+	return s.flagSet().Args()
+}
+
+// Dispatch runs the subcommand that is described in the given arguments.
+// The first argument is the name of the command, and the rest are the command flags and the positional arguments.
+//
+// `gvs help <command>` and `gvs <command> --help` print the help message of the command.
+//
+// If the command does not exist, the flags are not valid, or the command fails, Dispatch will return an error.
+func (s *FlagSet) Dispatch(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no command was given")
+	}
+
+	if args[0] == "help" {
+		if len(args) == 1 {
+			flag.Usage()
+			return nil
+		}
+
+		cmd := s.findCommand(args[1])
+		if cmd == nil {
+			return fmt.Errorf("unknown command %q", args[1])
+		}
+
+		s.printCommandUsage(cmd)
+		return nil
+	}
+
+	cmd := s.findCommand(args[0])
+	if cmd == nil {
+		return fmt.Errorf("unknown command %q, run %q to see the available commands", args[0], "gvs --help")
+	}
+
+	positional, err := cmd.flags.parseInterspersed(args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		s.printCommandUsage(cmd)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return cmd.run(positional)
+}
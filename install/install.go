@@ -23,6 +23,10 @@ type Installer interface {
 	// ExistingVersion installs again an already existing version as the current go version.
 	// ExistingVersion must return a non-null error if the unzip fails.
 	ExistingVersion(goVersionName string) error
+
+	// Download downloads, verifies and extracts the selected version without setting it as the current go version.
+	// Download must return a non-null error if any of the steps fails.
+	Download(ctx context.Context, fileName string, checksum string, goVersionName string) error
 }
 
 // Install is the struct that implements the Installer interface
@@ -54,28 +58,40 @@ func (i Install) compareChecksums(checksum string) error {
 	return nil
 }
 
-// createSymlink creates the symbolik links and updates the file that holds the currently installed version.
+// createSymlink creates the symbolik links and updates the file that holds the currently installed version,
+// together with the time the version was last used.
+//
+// When the shims are enabled, the symbolic links are not created, since the shims resolve the version on their own.
 //
 // If any of the above operations fail, createSymlink will return an error.
 func (i Install) createSymlink(goVersionName string) error {
-	if err := i.fileHelpers.CreateExecutableSymlink(goVersionName); err != nil {
-		return err
+	if !i.fileHelpers.AreShimsEnabled() {
+		if err := i.fileHelpers.CreateExecutableSymlink(goVersionName); err != nil {
+			return err
+		}
 	}
 
 	if err := i.fileHelpers.UpdateRecentVersion(goVersionName); err != nil {
 		return err
 	}
 
+	// the usage is only used from `gvs prune`, so the version is activated even if it can't be stored.
+	if err := i.fileHelpers.UpdateVersionUsage(goVersionName); err != nil {
+		i.log.Error("the usage of %s can't be stored: %s\n", goVersionName, err.Error())
+	}
+
 	return nil
 }
 
-// newVersionHandler is the request callback that handles all the logic to install the new version.
+// newVersionHandler is the request callback that handles all the logic to download the new version.
 //
 // newVersionHandler creates the tar file from the response body and then after validating the checksum,
-// it is unzipping the file and creates the symbolink liks. The unzipped directory is renamed to the selected
-// Go version. For example if the version name is `1.20.7`, the directory that contains the unzipped files will also
+// it is unzipping the file. The unzipped directory is renamed to the selected Go version.
+// For example if the version name is `1.20.7`, the directory that contains the unzipped files will also
 // be named `1.20.7`.
 //
+// newVersionHandler does not create the symbolic links, so the current go version is not affected.
+//
 // If any of the above operations fail, newVersionHandler will return an error.
 func (i Install) newVersionHandler(checksum string, goVersionName string) func(content io.ReadCloser) error {
 	return func(content io.ReadCloser) (err error) {
@@ -118,32 +134,45 @@ func (i Install) newVersionHandler(checksum string, goVersionName string) func(c
 			return err
 		}
 
-		if err = i.fileHelpers.RemoveTarFile(); err != nil {
-			return err
-		}
-
-		i.log.PrintMessage("Installing version...\n")
-		return i.createSymlink(goVersionName)
+		return i.fileHelpers.RemoveTarFile()
 	}
 }
 
-// NewVersion installs downloads and installs the selected version.
+// Download downloads, verifies and extracts the selected version.
 //
-// NewVersion is first making a request to download the tar file (using the clientAPI interface),
-// where it also passes the expected callback to handle the new version install logic.
+// Download is making a request to download the tar file (using the clientAPI interface),
+// where it also passes the expected callback to handle the download logic.
+// Unlike NewVersion, Download does not create the symbolic links and does not update the
+// file that holds the currently installed version, so it can be used to prefetch versions.
 //
-// If the request or the version install fails, NewVersion will return an error.
-func (i Install) NewVersion(ctx context.Context, fileName string, checksum string, goVersionName string) error {
+// If the request or the extraction of the version fails, Download will return an error.
+func (i Install) Download(ctx context.Context, fileName string, checksum string, goVersionName string) error {
 	i.log.PrintMessage("Downloading...\n")
 	return i.clientAPI.DownloadVersion(ctx, fileName, i.newVersionHandler(checksum, goVersionName))
 }
 
+// NewVersion installs downloads and installs the selected version.
+//
+// NewVersion is first downloading the version (using the Download method) and then
+// creates the symbolic links to set it as the current go version.
+//
+// If the download or the version install fails, NewVersion will return an error.
+func (i Install) NewVersion(ctx context.Context, fileName string, checksum string, goVersionName string) error {
+	if err := i.Download(ctx, fileName, checksum, goVersionName); err != nil {
+		return err
+	}
+
+	i.log.PrintMessage("Installing version...\n")
+	return i.createSymlink(goVersionName)
+}
+
 // ExistingVersion installs again an already existing version as the current go version.
 // And existing version is a version that has been downloaded in the past and therefore the contents
 // still exist on the local sysem.
 //
 // Since the version is already downloaded before, ExistingVersion only creates the new symblink link
-// to override the existing go version.
+// to override the existing go version. This is also the activation step for versions that were
+// prefetched with Download.
 //
 // If the symbolink link creating fails, ExistingVersion will return an error.
 func (i Install) ExistingVersion(goVersionName string) error {
@@ -297,3 +297,157 @@ func TestInstallNewVersionFailRequest(t *testing.T) {
 		t.Errorf("Error should be %q, instead got %q", expectedError.Error(), err.Error())
 	}
 }
+
+func TestInstallDownloadSuccess(t *testing.T) {
+	printer := &testutils.FakeStdout{}
+
+	version := "go1.21.0"
+	checksum := "some_checksum"
+
+	fileHelpers := &testutils.FakeFilesHelper{
+		Checksum: checksum,
+	}
+	clientAPI := testutils.FakeGoClientAPI{}
+	logger := logger.New(printer, nil)
+
+	installer := install.New(fileHelpers, clientAPI, logger)
+
+	err := installer.Download(context.Background(), "some_file_name", checksum, version)
+
+	if err != nil {
+		t.Errorf("Error should be nil, instead got %q", err.Error())
+	}
+
+	if fileHelpers.CreateExecutableSymlinkCalled {
+		t.Errorf("CreateExecutableSymlink should not have been called")
+	}
+
+	if fileHelpers.UpdateRecentVersionCalled {
+		t.Errorf("UpdateRecentVersion should not have been called")
+	}
+
+	printedMessages := printer.GetPrintMessages()
+	expectedPrintedMessages := []string{
+		"Downloading...\n",
+		"Compare Checksums...\n",
+		"Unzipping...\n",
+	}
+	if !cmp.Equal(printedMessages, expectedPrintedMessages) {
+		t.Errorf("Wrong logs received, got=%s", cmp.Diff(expectedPrintedMessages, printedMessages))
+	}
+}
+
+func TestInstallDownloadFailChecksumMissmatch(t *testing.T) {
+	version := "go1.21.0"
+	checksum := "some_checksum"
+
+	fileHelpers := &testutils.FakeFilesHelper{
+		Checksum: "some_other_checksum",
+	}
+	clientAPI := testutils.FakeGoClientAPI{}
+	logger := logger.New(&testutils.FakeStdout{}, nil)
+
+	installer := install.New(fileHelpers, clientAPI, logger)
+
+	err := installer.Download(context.Background(), "some_file_name", checksum, version)
+
+	expectedError := fmt.Errorf("checksums do not match.\nExpected: %q\nGot: %q", checksum, "some_other_checksum")
+	if err.Error() != expectedError.Error() {
+		t.Errorf("Error should be %q, instead got %q", expectedError.Error(), err.Error())
+	}
+
+	if !fileHelpers.RemoveTarFileCalled {
+		t.Errorf("RemoveTarFileCalled has not been called")
+	}
+}
+
+func TestInstallNewVersionCreatesSymlinks(t *testing.T) {
+	version := "go1.21.0"
+	checksum := "some_checksum"
+
+	fileHelpers := &testutils.FakeFilesHelper{
+		Checksum: checksum,
+	}
+	clientAPI := testutils.FakeGoClientAPI{}
+	logger := logger.New(&testutils.FakeStdout{}, nil)
+
+	installer := install.New(fileHelpers, clientAPI, logger)
+
+	err := installer.NewVersion(context.Background(), "some_file_name", checksum, version)
+
+	if err != nil {
+		t.Errorf("Error should be nil, instead got %q", err.Error())
+	}
+
+	if !fileHelpers.CreateExecutableSymlinkCalled {
+		t.Errorf("CreateExecutableSymlink should have been called")
+	}
+
+	if !fileHelpers.UpdateRecentVersionCalled {
+		t.Errorf("UpdateRecentVersion should have been called")
+	}
+}
+
+func TestInstallExistingVersionSkipsSymlinksWithShims(t *testing.T) {
+	version := "go1.21.0"
+
+	fileHelpers := &testutils.FakeFilesHelper{
+		ShimsEnabled: true,
+	}
+	clientAPI := testutils.FakeGoClientAPI{}
+	logger := logger.New(&testutils.FakeStdout{}, nil)
+
+	installer := install.New(fileHelpers, clientAPI, logger)
+
+	err := installer.ExistingVersion(version)
+
+	if err != nil {
+		t.Errorf("Error should be nil, instead got %q", err.Error())
+	}
+
+	if fileHelpers.CreateExecutableSymlinkCalled {
+		t.Errorf("CreateExecutableSymlink should not have been called")
+	}
+
+	if !fileHelpers.UpdateRecentVersionCalled {
+		t.Errorf("UpdateRecentVersion should have been called")
+	}
+}
+
+func TestInstallExistingVersionUpdatesUsage(t *testing.T) {
+	version := "go1.21.0"
+
+	fileHelpers := &testutils.FakeFilesHelper{}
+	clientAPI := testutils.FakeGoClientAPI{}
+	logger := logger.New(&testutils.FakeStdout{}, nil)
+
+	installer := install.New(fileHelpers, clientAPI, logger)
+
+	err := installer.ExistingVersion(version)
+
+	if err != nil {
+		t.Errorf("Error should be nil, instead got %q", err.Error())
+	}
+
+	if !cmp.Equal(fileHelpers.UsedVersions, []string{version}) {
+		t.Errorf("Wrong used versions, got=%s", cmp.Diff(fileHelpers.UsedVersions, []string{version}))
+	}
+}
+
+func TestInstallExistingVersionIgnoresUsageError(t *testing.T) {
+	version := "go1.21.0"
+
+	fileHelpers := &testutils.FakeFilesHelper{
+		UpdateVersionUsageError: fmt.Errorf("usage error"),
+	}
+	clientAPI := testutils.FakeGoClientAPI{}
+	logger := logger.New(&testutils.FakeStdout{}, nil)
+
+	installer := install.New(fileHelpers, clientAPI, logger)
+
+	err := installer.ExistingVersion(version)
+
+	if err != nil {
+		t.Errorf("Error should be nil, instead got %q", err.Error())
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"encoding/json"
 	"io"
 	"os"
-
+	"path/filepath"
 	"slices"
 
 	"github.com/VassilisPallas/gvs/api_client"
@@ -20,13 +20,52 @@ type FakeFilesHelper struct {
 	CacheResponseError           error
 	DeleteDirectoryError         error
 	GetTarChecksumError          error
+	GetAliasesError              error
+	StoreAliasesError            error
+	FindModFileError             error
+	FindVersionFileError         error
+	GetGlobalVersionError        error
+	WriteVersionError            error
+	ReadVersionFromModError      error
+	GetInstalledVersionsError    error
+	CreateShimsError             error
+	RemoveShimsError             error
+	GetAPIFeaturesError          error
+	UpdateVersionUsageError      error
+	GetVersionSizeError          error
+	GetPrunePolicyError          error
+	StorePrunePolicyError        error
+	RemoveSymlinksError          error
+	ClearRecentVersionError      error
 
 	Checksum                  string
 	RecentVersion             string
+	PreviousVersion           string
 	CachedVersion             bool
 	AlreadyDownloadedVersions []string
+	Aliases                   map[string]string
+	ModFile                   string
+	ModGoVersions             []string
+	ModToolchains             []string
+	VersionFile               string
+	VersionFileVersion        string
+	LocalVersion              string
+	GlobalVersion             string
+	InstalledVersions         []string
+	ShimsEnabled              bool
+	ShimsAutoInstall          bool
+	ShimsExecutable           string
+	APIFeatures               map[string][]string
+	RecentlyUsedVersions      []string
+	UsedVersions              []string
+	VersionSizes              map[string]int64
+	PrunePolicy               []byte
+	GorootBinaries            []string
 
-	RemoveTarFileCalled bool
+	RemoveTarFileCalled           bool
+	CreateExecutableSymlinkCalled bool
+	UpdateRecentVersionCalled     bool
+	RemoveSymlinksCalled          bool
 }
 
 func (fh FakeFilesHelper) CreateTarFile(content io.ReadCloser) error {
@@ -50,14 +89,54 @@ func (fh *FakeFilesHelper) RemoveTarFile() error {
 	return fh.RemoveTarFileError
 }
 
-func (fh FakeFilesHelper) CreateExecutableSymlink(goVersionName string) error {
+func (fh *FakeFilesHelper) CreateExecutableSymlink(goVersionName string) error {
+	fh.CreateExecutableSymlinkCalled = true
 	return fh.CreateExecutableSymlinkError
 }
 
-func (fh FakeFilesHelper) UpdateRecentVersion(goVersionName string) error {
+func (fh FakeFilesHelper) AreShimsEnabled() bool {
+	return fh.ShimsEnabled
+}
+
+func (fh *FakeFilesHelper) CreateShims(executable string, autoInstall bool) error {
+	if fh.CreateShimsError != nil {
+		return fh.CreateShimsError
+	}
+
+	fh.ShimsEnabled = true
+	fh.ShimsExecutable = executable
+	fh.ShimsAutoInstall = autoInstall
+	return nil
+}
+
+func (fh *FakeFilesHelper) RemoveShims() error {
+	if fh.RemoveShimsError != nil {
+		return fh.RemoveShimsError
+	}
+
+	fh.ShimsEnabled = false
+	return nil
+}
+
+func (fh *FakeFilesHelper) UpdateRecentVersion(goVersionName string) error {
+	fh.UpdateRecentVersionCalled = true
 	return fh.UpdateRecentVersionError
 }
 
+func (fh *FakeFilesHelper) RemoveExecutableSymlinks(goVersionName string) error {
+	fh.RemoveSymlinksCalled = true
+	return fh.RemoveSymlinksError
+}
+
+func (fh *FakeFilesHelper) ClearRecentVersion() error {
+	if fh.ClearRecentVersionError != nil {
+		return fh.ClearRecentVersionError
+	}
+
+	fh.RecentVersion = ""
+	return nil
+}
+
 func (FakeFilesHelper) StoreVersionsResponse(body []byte) error {
 	return nil
 }
@@ -127,6 +206,32 @@ func (fh FakeFilesHelper) GetRecentVersion() string {
 	return fh.RecentVersion
 }
 
+func (fh FakeFilesHelper) GetPreviousVersion() string {
+	return fh.PreviousVersion
+}
+
+func (fh FakeFilesHelper) GetAliases() (map[string]string, error) {
+	if fh.GetAliasesError != nil {
+		return nil, fh.GetAliasesError
+	}
+
+	aliases := map[string]string{}
+	for name, version := range fh.Aliases {
+		aliases[name] = version
+	}
+
+	return aliases, nil
+}
+
+func (fh *FakeFilesHelper) StoreAliases(aliases map[string]string) error {
+	if fh.StoreAliasesError != nil {
+		return fh.StoreAliasesError
+	}
+
+	fh.Aliases = aliases
+	return nil
+}
+
 func (fh FakeFilesHelper) DirectoryExists(goVersion string) bool {
 	return slices.Contains(fh.AlreadyDownloadedVersions, goVersion)
 }
@@ -147,6 +252,86 @@ func (fh FakeFilesHelper) GetLatestCreatedGoVersionDirectory() (string, error) {
 	return "", nil
 }
 
-func (fh FakeFilesHelper) ReadVersionFromMod() (string, error) {
-	return "", nil
+func (fh FakeFilesHelper) GetInstalledVersions() ([]string, error) {
+	return fh.InstalledVersions, fh.GetInstalledVersionsError
+}
+
+func (fh FakeFilesHelper) GetVersionDirectory(goVersion string) string {
+	return "/home/.gvs/.go.versions/" + goVersion
+}
+
+func (fh FakeFilesHelper) GetGorootBinary(goroot string, name string) string {
+	if !slices.Contains(fh.GorootBinaries, name) {
+		return ""
+	}
+
+	return filepath.Join(goroot, "bin", name)
+}
+
+func (fh *FakeFilesHelper) UpdateVersionUsage(goVersion string) error {
+	if fh.UpdateVersionUsageError != nil {
+		return fh.UpdateVersionUsageError
+	}
+
+	fh.UsedVersions = append(fh.UsedVersions, goVersion)
+	return nil
+}
+
+func (fh FakeFilesHelper) IsVersionUsedWithin(goVersion string, days int) bool {
+	return slices.Contains(fh.RecentlyUsedVersions, goVersion)
+}
+
+func (fh FakeFilesHelper) GetVersionSize(goVersion string) (int64, error) {
+	return fh.VersionSizes[goVersion], fh.GetVersionSizeError
+}
+
+func (fh FakeFilesHelper) GetPrunePolicy() ([]byte, error) {
+	return fh.PrunePolicy, fh.GetPrunePolicyError
+}
+
+func (fh *FakeFilesHelper) StorePrunePolicy(content []byte) error {
+	if fh.StorePrunePolicyError != nil {
+		return fh.StorePrunePolicyError
+	}
+
+	fh.PrunePolicy = content
+	return nil
+}
+
+func (fh FakeFilesHelper) GetAPIFeatures(goVersion string) ([]string, error) {
+	return fh.APIFeatures[goVersion], fh.GetAPIFeaturesError
+}
+
+func (fh FakeFilesHelper) FindVersionFile() (string, string, error) {
+	return fh.VersionFile, fh.VersionFileVersion, fh.FindVersionFileError
+}
+
+func (fh *FakeFilesHelper) WriteLocalVersion(version string) (string, error) {
+	if fh.WriteVersionError != nil {
+		return "", fh.WriteVersionError
+	}
+
+	fh.LocalVersion = version
+	return "/project/.go-version", nil
+}
+
+func (fh FakeFilesHelper) GetGlobalVersion() (string, string, error) {
+	return "/home/.gvs/.go-version", fh.GlobalVersion, fh.GetGlobalVersionError
+}
+
+func (fh *FakeFilesHelper) WriteGlobalVersion(version string) (string, error) {
+	if fh.WriteVersionError != nil {
+		return "", fh.WriteVersionError
+	}
+
+	fh.GlobalVersion = version
+	return "/home/.gvs/.go-version", nil
+}
+
+func (fh FakeFilesHelper) FindModFile() (string, error) {
+	return fh.ModFile, fh.FindModFileError
+}
+
+func (fh FakeFilesHelper) ReadVersionFromMod(path string) ([]string, []string, error) {
+	return fh.ModGoVersions, fh.ModToolchains, fh.ReadVersionFromModError
 }
@@ -45,6 +45,18 @@ type FakeFileSystem struct {
 
 	OpenFileMockFile *os.File
 	OpenFileError    error
+
+	WorkingDir string
+	GetwdError error
+
+	// Files contains the content for each file path. When it's not nil, ReadFile and Stat
+	// (and Lstat) use it instead of ReadFileBytes and StatMockResponse, and the missing paths do not exist.
+	// WriteFile, Rename and Remove update it as well, so the written files can be checked.
+	Files map[string][]byte
+
+	// Links contains the destination of each symbolic link path, which is returned from Readlink.
+	// The paths that are not in Links are not symbolic links.
+	Links map[string]string
 }
 
 func (fs FakeFileSystem) Chmod(name string, mode ioFS.FileMode) error {
@@ -68,14 +80,35 @@ func (fs FakeFileSystem) ReadDir(name string) ([]ioFS.DirEntry, error) {
 }
 
 func (fs FakeFileSystem) ReadFile(name string) ([]byte, error) {
+	if fs.Files != nil {
+		content, ok := fs.Files[name]
+		if !ok {
+			return nil, ioFS.ErrNotExist
+		}
+
+		return content, nil
+	}
+
 	return fs.ReadFileBytes, fs.ReadFileError
 }
 
 func (fs FakeFileSystem) Stat(name string) (ioFS.FileInfo, error) {
+	if fs.Files != nil {
+		if _, ok := fs.Files[name]; !ok {
+			return nil, ioFS.ErrNotExist
+		}
+
+		return FakeFileInfo{FileName: name}, nil
+	}
+
 	return fs.StatMockResponse, fs.StatError
 }
 
-func (FakeFileSystem) Lstat(name string) (ioFS.FileInfo, error) {
+func (fs FakeFileSystem) Lstat(name string) (ioFS.FileInfo, error) {
+	if fs.Files != nil {
+		return fs.Stat(name)
+	}
+
 	return nil, nil
 }
 
@@ -87,7 +120,20 @@ func (fs FakeFileSystem) Symlink(oldname string, newname string) error {
 	return fs.SymlinkError
 }
 
+func (fs FakeFileSystem) Readlink(name string) (string, error) {
+	destination, ok := fs.Links[name]
+	if !ok {
+		return "", ioFS.ErrInvalid
+	}
+
+	return destination, nil
+}
+
 func (fs FakeFileSystem) WriteFile(name string, data []byte, perm ioFS.FileMode) error {
+	if fs.Files != nil && fs.WriteFileError == nil {
+		fs.Files[name] = data
+	}
+
 	return fs.WriteFileError
 }
 
@@ -108,10 +154,28 @@ func (fs FakeFileSystem) MkdirIfNotExist(path string, perm ioFS.FileMode) error
 }
 
 func (fs FakeFileSystem) Rename(oldpath string, newpath string) error {
+	if fs.Files != nil && fs.RenameError == nil {
+		content, ok := fs.Files[oldpath]
+		if !ok {
+			return ioFS.ErrNotExist
+		}
+
+		fs.Files[newpath] = content
+		delete(fs.Files, oldpath)
+	}
+
 	return fs.RenameError
 }
 
 func (fs FakeFileSystem) Remove(name string) error {
+	if fs.Files != nil && fs.RemoveError == nil {
+		if _, ok := fs.Files[name]; !ok {
+			return ioFS.ErrNotExist
+		}
+
+		delete(fs.Files, name)
+	}
+
 	return fs.RemoveError
 }
 
@@ -122,3 +186,7 @@ func (fs FakeFileSystem) RemoveAll(path string) error {
 func (fs FakeFileSystem) GetHomeDirectory() string {
 	return fs.HomeDir
 }
+
+func (fs FakeFileSystem) Getwd() (string, error) {
+	return fs.WorkingDir, fs.GetwdError
+}
@@ -25,6 +25,7 @@ func (tc MockClient) Do(req *http.Request) (*http.Response, error) {
 type FakeGoClientAPI struct {
 	DownloadError      error
 	FetchVersionsError error
+	FetchedVersions    []api_client.VersionInfo
 }
 
 func (ga FakeGoClientAPI) FetchVersions(ctx context.Context, v *[]api_client.VersionInfo) error {
@@ -32,6 +33,11 @@ func (ga FakeGoClientAPI) FetchVersions(ctx context.Context, v *[]api_client.Ver
 		return ga.FetchVersionsError
 	}
 
+	if ga.FetchedVersions != nil {
+		*v = ga.FetchedVersions
+		return nil
+	}
+
 	responseVersions := []map[string]interface{}{
 		{
 			"version": "go1.21.0",
@@ -7,9 +7,11 @@ import (
 type FakeInstaller struct {
 	NewVersionError      error
 	ExistingVersionError error
+	DownloadError        error
 
 	ExistingVersionCalled bool
 	NewVersionCalled      bool
+	DownloadCalled        bool
 }
 
 func (fi *FakeInstaller) NewVersion(ctx context.Context, fileName string, checksum string, goVersionName string) error {
@@ -21,3 +23,8 @@ func (fi *FakeInstaller) ExistingVersion(goVersionName string) error {
 	fi.ExistingVersionCalled = true
 	return fi.ExistingVersionError
 }
+
+func (fi *FakeInstaller) Download(ctx context.Context, fileName string, checksum string, goVersionName string) error {
+	fi.DownloadCalled = true
+	return fi.DownloadError
+}
@@ -35,6 +35,9 @@ type Logger interface {
 	// SetLogWriter specified the output destination for the logger.
 	SetLogWriter(logWriter io.WriteCloser)
 
+	// SetCliWriter specified the output destination for the cli messages.
+	SetCliWriter(cliWriter io.Writer)
+
 	// Close closed the logWriter instance that is passed to the method SetLogWriter (if any).
 	Close()
 }
@@ -160,6 +163,14 @@ func (l *Log) SetLogWriter(logWriter io.WriteCloser) {
 	l.logger.SetOutput(logWriter)
 }
 
+// SetCliWriter sets the output destination for the cli messages
+//
+// It can be used when the standard output is reserved for another program
+// (e.g. when a Go binary runs from a shim), so the messages are printed to the standard error instead.
+func (l *Log) SetCliWriter(cliWriter io.Writer) {
+	l.cliWriter = cliWriter
+}
+
 // New returns a *Log instance that implements the Logger interface.
 // Each call to New returns a distinct *Log instance even if the parameters are identical.
 func New(cliWriter io.Writer, logWriter io.WriteCloser) *Log {
@@ -33,6 +33,27 @@ func TestPrintMessage(t *testing.T) {
 	}
 }
 
+func TestSetCliWriter(t *testing.T) {
+	msg := "some message\n"
+	cliWriter := &testutils.FakeStdout{}
+	newCliWriter := &testutils.FakeStdout{}
+
+	log := logger.New(cliWriter, nil)
+	log.SetCliWriter(newCliWriter)
+
+	log.PrintMessage(msg)
+
+	if len(cliWriter.GetPrintMessages()) != 0 {
+		t.Errorf("the previous cli writer should not receive any message, got=%v", cliWriter.GetPrintMessages())
+	}
+
+	printedMessages := newCliWriter.GetPrintMessages()
+	expectedPrintedMessages := []string{msg}
+	if !cmp.Equal(printedMessages, expectedPrintedMessages) {
+		t.Errorf("Wrong print messages received, got=%s", cmp.Diff(expectedPrintedMessages, printedMessages))
+	}
+}
+
 func TestPrintError(t *testing.T) {
 	msg := "some error\n"
 	cliWriter := &testutils.FakeStdout{}
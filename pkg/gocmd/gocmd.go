@@ -0,0 +1,77 @@
+// Package gocmd provides an interface for running
+// programs with the installed Go versions.
+package gocmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/VassilisPallas/gvs/shell"
+)
+
+// BinaryFinder is the interface that wraps the method for finding the binaries of a GOROOT.
+type BinaryFinder interface {
+	// GetGorootBinary returns the path of the given binary (e.g. `go`) inside the bin directory of the given GOROOT.
+	// GetGorootBinary must return an empty string if the binary doesn't exist.
+	GetGorootBinary(goroot string, name string) string
+}
+
+// Commander is the interface that wraps the basic methods for running programs with a GOROOT.
+type Commander interface {
+	// NewCommand returns the command that runs the given program with the given GOROOT.
+	NewCommand(goroot string, name string, args []string) *exec.Cmd
+}
+
+// Command is the struct that implements the Commander interface.
+type Command struct {
+	// binaries is used to find the binaries of the GOROOT.
+	binaries BinaryFinder
+}
+
+// NewCommand returns the command that runs the given program with the given arguments, where the GOROOT is set
+// and its bin directory is prepended to the PATH, so the Go binaries the program runs are of the same version.
+// The bin directory of the GOROOT that is set from the shell hook (or `gvs shell`) is removed from the PATH.
+// If the program is one of the binaries of the GOROOT (e.g. `go`), the binary of the GOROOT is used.
+//
+// GOTOOLCHAIN is set to `local`, so the `go` command doesn't switch to the toolchain of the `go` line of a go.mod
+// (or the one that is set with `go env -w`), which would run a different version than the one of the GOROOT.
+func (c Command) NewCommand(goroot string, name string, args []string) *exec.Cmd {
+	if !strings.ContainsRune(name, os.PathSeparator) {
+		if binary := c.binaries.GetGorootBinary(goroot, name); binary != "" {
+			name = binary
+		}
+	}
+
+	cmd := exec.Command(name, args...)
+
+	path := shell.SwitchPath(os.Getenv("PATH"), os.Getenv(shell.HookGorootEnvName), goroot)
+	cmd.Env = append(os.Environ(), "GOROOT="+goroot, "PATH="+strings.Join(path, string(os.PathListSeparator)), "GOTOOLCHAIN=local")
+
+	return cmd
+}
+
+// GetExitCode returns the exit code of the program that failed with the given error, which is the exit code
+// that gvs has to exit with as well. If the program is killed from a signal, the exit code is 128 plus the number
+// of the signal, the same as the shells do.
+//
+// If the error is not an error of the type *exec.ExitError, GetExitCode returns back false.
+func GetExitCode(err error) (int, bool) {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return 0, false
+	}
+
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal()), true
+	}
+
+	return exitErr.ExitCode(), true
+}
+
+// New returns a Command instance that implements the Commander interface.
+func New(binaries BinaryFinder) Command {
+	return Command{binaries: binaries}
+}
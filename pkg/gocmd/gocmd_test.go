@@ -0,0 +1,144 @@
+package gocmd_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/pkg/gocmd"
+	"github.com/VassilisPallas/gvs/shell"
+)
+
+// getEnv returns the last value of the given environment variable from the given environment, which is the value the command uses.
+func getEnv(env []string, name string) string {
+	value := ""
+	for _, variable := range env {
+		if strings.HasPrefix(variable, name+"=") {
+			value = strings.TrimPrefix(variable, name+"=")
+		}
+	}
+
+	return value
+}
+
+func TestNewCommand(t *testing.T) {
+	goroot := "/home/.gvs/.go.versions/go1.21.5"
+
+	testCases := []struct {
+		testTitle      string
+		name           string
+		gorootBinaries []string
+		expectedPath   string
+	}{
+		{
+			testTitle:      "should use the binary of the GOROOT",
+			name:           "go",
+			gorootBinaries: []string{"go", "gofmt"},
+			expectedPath:   filepath.Join(goroot, "bin", "go"),
+		},
+		{
+			testTitle:      "should use the given program when it's not a binary of the GOROOT",
+			name:           "/bin/sh",
+			gorootBinaries: []string{"go", "gofmt"},
+			expectedPath:   "/bin/sh",
+		},
+		{
+			testTitle:      "should use the given path even if a binary of the GOROOT has the same name",
+			name:           "./go",
+			gorootBinaries: []string{"go", "gofmt"},
+			expectedPath:   "./go",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			t.Setenv("PATH", strings.Join([]string{"/home/.gvs/.go.versions/go1.20.14/bin", "/usr/bin"}, string(os.PathListSeparator)))
+			t.Setenv(shell.HookGorootEnvName, "/home/.gvs/.go.versions/go1.20.14")
+
+			t.Setenv("GOTOOLCHAIN", "go1.22.0")
+
+			commander := gocmd.New(testutils.FakeFilesHelper{GorootBinaries: tc.gorootBinaries})
+
+			cmd := commander.NewCommand(goroot, tc.name, []string{"version"})
+
+			if cmd.Args[0] != tc.expectedPath {
+				t.Errorf("program should be %q, instead got %q", tc.expectedPath, cmd.Args[0])
+			}
+
+			if len(cmd.Args) != 2 || cmd.Args[1] != "version" {
+				t.Errorf("arguments should be %q, instead got %q", []string{"version"}, cmd.Args[1:])
+			}
+
+			if res := getEnv(cmd.Env, "GOROOT"); res != goroot {
+				t.Errorf("GOROOT should be %q, instead got %q", goroot, res)
+			}
+
+			expectedPath := strings.Join([]string{filepath.Join(goroot, "bin"), "/usr/bin"}, string(os.PathListSeparator))
+			if res := getEnv(cmd.Env, "PATH"); res != expectedPath {
+				t.Errorf("PATH should be %q, instead got %q", expectedPath, res)
+			}
+
+			if res := getEnv(cmd.Env, "GOTOOLCHAIN"); res != "local" {
+				t.Errorf("GOTOOLCHAIN should be %q, instead got %q", "local", res)
+			}
+		})
+	}
+}
+
+func TestGetExitCode(t *testing.T) {
+	goroot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(goroot, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(goroot, "bin", "go"), []byte("#!/bin/sh\nexit \"$1\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	commander := gocmd.New(testutils.FakeFilesHelper{GorootBinaries: []string{"go"}})
+
+	testCases := []struct {
+		testTitle        string
+		run              func() error
+		expectedExitCode int
+		expectedOk       bool
+	}{
+		{
+			testTitle:        "should return the exit code of the binary of the GOROOT",
+			run:              commander.NewCommand(goroot, "go", []string{"3"}).Run,
+			expectedExitCode: 3,
+			expectedOk:       true,
+		},
+		{
+			testTitle:        "should return 128 plus the signal when the program is killed",
+			run:              commander.NewCommand(goroot, "/bin/sh", []string{"-c", "kill -TERM $$"}).Run,
+			expectedExitCode: 143,
+			expectedOk:       true,
+		},
+		{
+			testTitle:        "should return false when the error is not an exit error",
+			run:              func() error { return errors.New("an error occurred") },
+			expectedExitCode: 0,
+			expectedOk:       false,
+		},
+		{
+			testTitle:        "should return false when the program passes",
+			run:              commander.NewCommand(goroot, "go", []string{"0"}).Run,
+			expectedExitCode: 0,
+			expectedOk:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			exitCode, ok := gocmd.GetExitCode(tc.run())
+
+			if exitCode != tc.expectedExitCode || ok != tc.expectedOk {
+				t.Errorf("result should be (%d, %t), instead got (%d, %t)", tc.expectedExitCode, tc.expectedOk, exitCode, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Package shell provides the scripts that integrate gvs with the supported shells,
+// so the Go version can be changed for a single shell session.
+package shell
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// Bash is the name of the bash shell.
+	Bash = "bash"
+
+	// Zsh is the name of the zsh shell.
+	Zsh = "zsh"
+
+	// Fish is the name of the fish shell.
+	Fish = "fish"
+)
+
+const (
+	// HookGorootEnvName is the name of the environment variable where the hook (or `gvs shell`) stores the GOROOT it has set,
+	// so its bin directory can be removed from the PATH when the version changes.
+	HookGorootEnvName = "GVS_HOOK_GOROOT"
+
+	// HookMissingEnvName is the name of the environment variable where the hook stores the version that is not installed,
+	// so the warning is printed only once.
+	HookMissingEnvName = "GVS_HOOK_MISSING"
+
+	// versionEnvName is the name of the environment variable that overrides the version of the current directory.
+	// It's the same as version.VersionEnvName.
+	versionEnvName = "GVS_VERSION"
+)
+
+// shells contains all the supported shells.
+var shells = []string{Bash, Zsh, Fish}
+
+// hookScripts contains the hook script of each shell. The `%[1]s` is replaced with the quoted path of the gvs executable.
+//
+// The hooks run `gvs hook-env` before every prompt, which prints the commands that update the environment of the shell.
+var hookScripts = map[string]string{
+	Bash: `_gvs_hook() {
+  local previous_exit_status=$?
+  eval "$(%[1]s hook-env bash)"
+  return $previous_exit_status
+}
+if [[ ";${PROMPT_COMMAND[*]:-};" != *";_gvs_hook;"* ]]; then
+  PROMPT_COMMAND="_gvs_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`,
+	Zsh: `_gvs_hook() {
+  eval "$(%[1]s hook-env zsh)"
+}
+typeset -ag precmd_functions
+if (( ! ${precmd_functions[(I)_gvs_hook]} )); then
+  precmd_functions=(_gvs_hook $precmd_functions)
+fi
+`,
+	Fish: `function _gvs_hook --on-event fish_prompt
+    %[1]s hook-env fish | source
+end
+`,
+}
+
+// Detect returns the shell from the SHELL environment variable, or bash if it's not a supported shell.
+func Detect() string {
+	name := filepath.Base(os.Getenv("SHELL"))
+	if IsSupported(name) {
+		return name
+	}
+
+	return Bash
+}
+
+// IsSupported returns if the given shell is supported.
+func IsSupported(shell string) bool {
+	for _, s := range shells {
+		if s == shell {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkShell returns an error if the given shell is not supported.
+func checkShell(shell string) error {
+	if !IsSupported(shell) {
+		return fmt.Errorf("%q is not a supported shell, the supported shells are: %s", shell, strings.Join(shells, ", "))
+	}
+
+	return nil
+}
+
+// Hook returns the script that has to be evaluated from the given shell to switch the Go version
+// when the directory changes, where executable is the path of the gvs executable.
+//
+// If the shell is not supported, Hook returns back an error.
+func Hook(shell string, executable string) (string, error) {
+	if err := checkShell(shell); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(hookScripts[shell], Quote(shell, executable)), nil
+}
+
+// Quote returns the given value quoted for the given shell, so it can be used as a single argument.
+func Quote(shell string, value string) string {
+	if shell == Fish {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+		return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// Export returns the command that sets the given environment variable on the given shell.
+func Export(shell string, name string, value string) string {
+	if shell == Fish {
+		return fmt.Sprintf("set -gx %s %s;", name, Quote(shell, value))
+	}
+
+	return fmt.Sprintf("export %s=%s;", name, Quote(shell, value))
+}
+
+// ExportPath returns the command that sets the PATH environment variable to the given directories on the given shell.
+func ExportPath(shell string, dirs []string) string {
+	if shell == Fish {
+		quoted := make([]string, 0, len(dirs))
+		for _, dir := range dirs {
+			quoted = append(quoted, Quote(shell, dir))
+		}
+
+		return fmt.Sprintf("set -gx PATH %s;", strings.Join(quoted, " "))
+	}
+
+	return Export(shell, "PATH", strings.Join(dirs, string(os.PathListSeparator)))
+}
+
+// Unset returns the command that removes the given environment variable from the given shell.
+func Unset(shell string, name string) string {
+	if shell == Fish {
+		return fmt.Sprintf("set -e %s;", name)
+	}
+
+	return fmt.Sprintf("unset %s;", name)
+}
+
+// Warn returns the command that prints the given message to the standard error of the given shell.
+func Warn(shell string, message string) string {
+	return fmt.Sprintf("echo %s >&2;", Quote(shell, message))
+}
+
+// SwitchPath returns the directories of the given PATH, where the bin directory of previousGoroot is removed
+// and the bin directory of goroot is prepended. Any of the GOROOTs can be empty.
+func SwitchPath(path string, previousGoroot string, goroot string) []string {
+	dirs := []string{}
+	if goroot != "" {
+		dirs = append(dirs, filepath.Join(goroot, "bin"))
+	}
+
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" || (previousGoroot != "" && dir == filepath.Join(previousGoroot, "bin")) {
+			continue
+		}
+
+		if goroot != "" && dir == filepath.Join(goroot, "bin") {
+			continue
+		}
+
+		dirs = append(dirs, dir)
+	}
+
+	return dirs
+}
+
+// HookEnv returns the commands that switch the given shell to the given GOROOT, which is printed from `gvs hook-env`.
+//
+// An empty goroot means that no installed version applies to the current directory, so the GOROOT that was set
+// before by the hook is removed. If missing is not empty, it's the version that applies to the current directory
+// but it's not installed, and a warning is printed the first time it's found.
+//
+// The previous state is read from the environment, and if nothing has changed, HookEnv returns back an empty string,
+// so running it before every prompt doesn't change the environment.
+//
+// If the shell is not supported, HookEnv returns back an error.
+func HookEnv(shell string, goroot string, missing string) (string, error) {
+	if err := checkShell(shell); err != nil {
+		return "", err
+	}
+
+	previousGoroot := os.Getenv(HookGorootEnvName)
+	previousMissing := os.Getenv(HookMissingEnvName)
+
+	if goroot == previousGoroot && missing == previousMissing {
+		return "", nil
+	}
+
+	commands := []string{}
+
+	if missing != previousMissing {
+		if missing == "" {
+			commands = append(commands, Unset(shell, HookMissingEnvName))
+		} else {
+			commands = append(commands, Export(shell, HookMissingEnvName, missing))
+			commands = append(commands, Warn(shell, fmt.Sprintf("gvs: %s is not installed, run 'gvs install --download-only %s' to install it", missing, missing)))
+		}
+	}
+
+	if goroot != previousGoroot {
+		commands = append(commands, ExportPath(shell, SwitchPath(os.Getenv("PATH"), previousGoroot, goroot)))
+
+		if goroot == "" {
+			commands = append(commands, Unset(shell, "GOROOT"), Unset(shell, HookGorootEnvName))
+		} else {
+			commands = append(commands, Export(shell, "GOROOT", goroot), Export(shell, HookGorootEnvName, goroot))
+		}
+	}
+
+	return strings.Join(commands, "\n"), nil
+}
+
+// SessionEnv returns the commands that switch the given shell to the given version and GOROOT, which are printed from `gvs shell`.
+//
+// The version is exported to the GVS_VERSION environment variable, so the shell hook and the shims use it as well,
+// and the bin directory of the GOROOT replaces the one that was set before from gvs on the PATH.
+//
+// If the shell is not supported, SessionEnv returns back an error.
+func SessionEnv(shell string, version string, goroot string) (string, error) {
+	if err := checkShell(shell); err != nil {
+		return "", err
+	}
+
+	commands := []string{
+		Export(shell, versionEnvName, version),
+		ExportPath(shell, SwitchPath(os.Getenv("PATH"), os.Getenv(HookGorootEnvName), goroot)),
+		Export(shell, "GOROOT", goroot),
+		Export(shell, HookGorootEnvName, goroot),
+	}
+
+	return strings.Join(commands, "\n"), nil
+}
+
+// UnsetSessionEnv returns the commands that revert the changes of SessionEnv on the given shell.
+//
+// If the shell is not supported, UnsetSessionEnv returns back an error.
+func UnsetSessionEnv(shell string) (string, error) {
+	if err := checkShell(shell); err != nil {
+		return "", err
+	}
+
+	commands := []string{
+		Unset(shell, versionEnvName),
+		ExportPath(shell, SwitchPath(os.Getenv("PATH"), os.Getenv(HookGorootEnvName), "")),
+		Unset(shell, "GOROOT"),
+		Unset(shell, HookGorootEnvName),
+	}
+
+	return strings.Join(commands, "\n"), nil
+}
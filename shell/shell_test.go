@@ -0,0 +1,291 @@
+package shell_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/shell"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHook(t *testing.T) {
+	testCases := []struct {
+		testTitle        string
+		shell            string
+		expectedContains string
+		expectedError    error
+	}{
+		{
+			testTitle:        "should return the bash hook",
+			shell:            "bash",
+			expectedContains: `eval "$('/usr/local/bin/gvs' hook-env bash)"`,
+		},
+		{
+			testTitle:        "should return the zsh hook",
+			shell:            "zsh",
+			expectedContains: `eval "$('/usr/local/bin/gvs' hook-env zsh)"`,
+		},
+		{
+			testTitle:        "should return the fish hook",
+			shell:            "fish",
+			expectedContains: `'/usr/local/bin/gvs' hook-env fish | source`,
+		},
+		{
+			testTitle:     "should return an error when the shell is not supported",
+			shell:         "tcsh",
+			expectedError: errors.New(`"tcsh" is not a supported shell, the supported shells are: bash, zsh, fish`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			script, err := shell.Hook(tc.shell, "/usr/local/bin/gvs")
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !strings.Contains(script, tc.expectedContains) {
+				t.Errorf("script should contain %q, instead got %q", tc.expectedContains, script)
+			}
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	testCases := []struct {
+		testTitle      string
+		shell          string
+		value          string
+		expectedQuoted string
+	}{
+		{
+			testTitle:      "should quote the value for bash",
+			shell:          "bash",
+			value:          "it's",
+			expectedQuoted: `'it'\''s'`,
+		},
+		{
+			testTitle:      "should quote the value for fish",
+			shell:          "fish",
+			value:          `it's \`,
+			expectedQuoted: `'it\'s \\'`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			quoted := shell.Quote(tc.shell, tc.value)
+
+			if quoted != tc.expectedQuoted {
+				t.Errorf("quoted value should be %q, instead got %q", tc.expectedQuoted, quoted)
+			}
+		})
+	}
+}
+
+func TestSwitchPath(t *testing.T) {
+	testCases := []struct {
+		testTitle      string
+		path           string
+		previousGoroot string
+		goroot         string
+		expectedDirs   []string
+	}{
+		{
+			testTitle:    "should prepend the bin directory of the GOROOT",
+			path:         "/usr/bin:/bin",
+			goroot:       "/home/.gvs/.go.versions/go1.21.5",
+			expectedDirs: []string{"/home/.gvs/.go.versions/go1.21.5/bin", "/usr/bin", "/bin"},
+		},
+		{
+			testTitle:      "should replace the bin directory of the previous GOROOT",
+			path:           "/home/.gvs/.go.versions/go1.20.1/bin:/usr/bin:/bin",
+			previousGoroot: "/home/.gvs/.go.versions/go1.20.1",
+			goroot:         "/home/.gvs/.go.versions/go1.21.5",
+			expectedDirs:   []string{"/home/.gvs/.go.versions/go1.21.5/bin", "/usr/bin", "/bin"},
+		},
+		{
+			testTitle:      "should remove the bin directory of the previous GOROOT",
+			path:           "/home/.gvs/.go.versions/go1.20.1/bin:/usr/bin:/bin",
+			previousGoroot: "/home/.gvs/.go.versions/go1.20.1",
+			expectedDirs:   []string{"/usr/bin", "/bin"},
+		},
+		{
+			testTitle:    "should not add the bin directory twice",
+			path:         "/usr/bin:/home/.gvs/.go.versions/go1.21.5/bin",
+			goroot:       "/home/.gvs/.go.versions/go1.21.5",
+			expectedDirs: []string{"/home/.gvs/.go.versions/go1.21.5/bin", "/usr/bin"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			dirs := shell.SwitchPath(tc.path, tc.previousGoroot, tc.goroot)
+
+			if !cmp.Equal(dirs, tc.expectedDirs) {
+				t.Errorf("Wrong directories received, got=%s", cmp.Diff(dirs, tc.expectedDirs))
+			}
+		})
+	}
+}
+
+func TestHookEnv(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		shell           string
+		previousGoroot  string
+		previousMissing string
+		goroot          string
+		missing         string
+		expectedScript  string
+	}{
+		{
+			testTitle: "should switch to the GOROOT",
+			shell:     "bash",
+			goroot:    "/home/.gvs/.go.versions/go1.21.5",
+			expectedScript: "export PATH='/home/.gvs/.go.versions/go1.21.5/bin:/usr/bin';\n" +
+				"export GOROOT='/home/.gvs/.go.versions/go1.21.5';\n" +
+				"export GVS_HOOK_GOROOT='/home/.gvs/.go.versions/go1.21.5';",
+		},
+		{
+			testTitle:      "should return an empty script when nothing has changed",
+			shell:          "bash",
+			previousGoroot: "/home/.gvs/.go.versions/go1.21.5",
+			goroot:         "/home/.gvs/.go.versions/go1.21.5",
+			expectedScript: "",
+		},
+		{
+			testTitle:      "should remove the GOROOT and warn for the missing version",
+			shell:          "fish",
+			previousGoroot: "/home/.gvs/.go.versions/go1.21.5",
+			missing:        "1.22",
+			expectedScript: "set -gx GVS_HOOK_MISSING '1.22';\n" +
+				"echo 'gvs: 1.22 is not installed, run \\'gvs install --download-only 1.22\\' to install it' >&2;\n" +
+				"set -gx PATH '/usr/bin';\n" +
+				"set -e GOROOT;\n" +
+				"set -e GVS_HOOK_GOROOT;",
+		},
+		{
+			testTitle:       "should not warn again for the same missing version",
+			shell:           "zsh",
+			previousMissing: "1.22",
+			missing:         "1.22",
+			expectedScript:  "",
+		},
+		{
+			testTitle:       "should remove the missing version",
+			shell:           "zsh",
+			previousMissing: "1.22",
+			expectedScript:  "unset GVS_HOOK_MISSING;",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			path := "/usr/bin"
+			if tc.previousGoroot != "" {
+				path = tc.previousGoroot + "/bin:" + path
+			}
+
+			t.Setenv("PATH", path)
+			t.Setenv(shell.HookGorootEnvName, tc.previousGoroot)
+			t.Setenv(shell.HookMissingEnvName, tc.previousMissing)
+
+			script, err := shell.HookEnv(tc.shell, tc.goroot, tc.missing)
+			if err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if script != tc.expectedScript {
+				t.Errorf("script should be %q, instead got %q", tc.expectedScript, script)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		testTitle     string
+		shellEnv      string
+		expectedShell string
+	}{
+		{
+			testTitle:     "should return the shell of the SHELL environment variable",
+			shellEnv:      "/usr/local/bin/fish",
+			expectedShell: "fish",
+		},
+		{
+			testTitle:     "should return bash when the shell is not supported",
+			shellEnv:      "/bin/tcsh",
+			expectedShell: "bash",
+		},
+		{
+			testTitle:     "should return bash when the SHELL environment variable is empty",
+			shellEnv:      "",
+			expectedShell: "bash",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			t.Setenv("SHELL", tc.shellEnv)
+
+			if detected := shell.Detect(); detected != tc.expectedShell {
+				t.Errorf("shell should be %q, instead got %q", tc.expectedShell, detected)
+			}
+		})
+	}
+}
+
+func TestSessionEnv(t *testing.T) {
+	t.Setenv("PATH", "/home/.gvs/.go.versions/go1.21.5/bin:/usr/bin")
+	t.Setenv(shell.HookGorootEnvName, "/home/.gvs/.go.versions/go1.21.5")
+
+	script, err := shell.SessionEnv("bash", "1.20.14", "/home/.gvs/.go.versions/go1.20.14")
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	expectedScript := "export GVS_VERSION='1.20.14';\n" +
+		"export PATH='/home/.gvs/.go.versions/go1.20.14/bin:/usr/bin';\n" +
+		"export GOROOT='/home/.gvs/.go.versions/go1.20.14';\n" +
+		"export GVS_HOOK_GOROOT='/home/.gvs/.go.versions/go1.20.14';"
+
+	if script != expectedScript {
+		t.Errorf("script should be %q, instead got %q", expectedScript, script)
+	}
+}
+
+func TestUnsetSessionEnv(t *testing.T) {
+	t.Setenv("PATH", "/home/.gvs/.go.versions/go1.20.14/bin:/usr/bin")
+	t.Setenv(shell.HookGorootEnvName, "/home/.gvs/.go.versions/go1.20.14")
+
+	script, err := shell.UnsetSessionEnv("fish")
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	expectedScript := "set -e GVS_VERSION;\n" +
+		"set -gx PATH '/usr/bin';\n" +
+		"set -e GOROOT;\n" +
+		"set -e GVS_HOOK_GOROOT;"
+
+	if script != expectedScript {
+		t.Errorf("script should be %q, instead got %q", expectedScript, script)
+	}
+
+	if _, err := shell.UnsetSessionEnv("tcsh"); err == nil {
+		t.Error("error should not be nil for an unsupported shell")
+	}
+}
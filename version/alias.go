@@ -0,0 +1,122 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/VassilisPallas/gvs/errors"
+)
+
+// aliasNameRegex describes the valid alias names (e.g. `work`, `legacy-api`).
+var aliasNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// Alias contains a user-defined name for a version.
+type Alias struct {
+	// Name is the name of the alias.
+	Name string
+
+	// Version is the version the alias points to. It can be anything that can be resolved (e.g. `1.21.6`, `1.19`, `~1.21`).
+	Version string
+}
+
+// validateAlias returns an error if the alias name can't be used, or if the version can't be resolved.
+//
+// The name can't be a version or a keyword, so the versions and the keywords are always resolved the same way.
+func validateAlias(name string, version string) error {
+	if !aliasNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid alias name %q, it should start with a letter and contain only letters, numbers, '-' and '_'", name)
+	}
+
+	if IsKeyword(name) || ParseSemver(name, &Semver{}) == nil {
+		return fmt.Errorf("invalid alias name %q, it can't be a version or a keyword", name)
+	}
+
+	return validateQuery(version)
+}
+
+// validateQuery returns an error if the query is neither a keyword, a constraint expression nor a version.
+func validateQuery(query string) error {
+	if IsKeyword(query) {
+		return nil
+	}
+
+	if IsConstraint(query) {
+		return ParseConstraint(query, &Constraint{})
+	}
+
+	return ParseSemver(query, &Semver{})
+}
+
+// SetAlias stores the given alias name for the version, replacing any existing alias with the same name.
+//
+// If the name or the version are not valid, or the alias can't be stored, SetAlias returns back an error.
+func (v Version) SetAlias(name string, version string) error {
+	if err := validateAlias(name, version); err != nil {
+		return err
+	}
+
+	aliases, err := v.fileHelpers.GetAliases()
+	if err != nil {
+		return err
+	}
+
+	aliases[name] = version
+	return v.fileHelpers.StoreAliases(aliases)
+}
+
+// DeleteAlias deletes the alias with the given name.
+//
+// If the alias does not exist, an error of the type *AliasNotFoundError is returned.
+func (v Version) DeleteAlias(name string) error {
+	aliases, err := v.fileHelpers.GetAliases()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := aliases[name]; !ok {
+		return &errors.AliasNotFoundError{Name: name}
+	}
+
+	delete(aliases, name)
+	return v.fileHelpers.StoreAliases(aliases)
+}
+
+// GetAliases returns the user-defined aliases, sorted by name.
+func (v Version) GetAliases() ([]Alias, error) {
+	aliases, err := v.fileHelpers.GetAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Alias, 0, len(aliases))
+	for name, version := range aliases {
+		res = append(res, Alias{Name: name, Version: version})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
+
+// GetAliasesForVersion returns the names of the aliases that are resolved to the given version from the given versions.
+func (v Version) GetAliasesForVersion(evs []*ExtendedVersion, ev *ExtendedVersion) ([]string, error) {
+	aliases, err := v.GetAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, alias := range aliases {
+		resolved, err := v.resolveQuery(evs, alias.Version, false)
+		if err == nil && resolved == ev {
+			names = append(names, alias.Name)
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,219 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSetAlias(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		name            string
+		version         string
+		storeError      error
+		expectedAliases map[string]string
+		expectedError   error
+	}{
+		{
+			testTitle:       "should store the alias",
+			name:            "work",
+			version:         "1.21.6",
+			expectedAliases: map[string]string{"legacy": "1.19", "work": "1.21.6"},
+			expectedError:   nil,
+		},
+		{
+			testTitle:       "should replace an existing alias",
+			name:            "legacy",
+			version:         "~1.20",
+			expectedAliases: map[string]string{"legacy": "~1.20"},
+			expectedError:   nil,
+		},
+		{
+			testTitle:       "should store an alias to a keyword",
+			name:            "old",
+			version:         "oldstable",
+			expectedAliases: map[string]string{"legacy": "1.19", "old": "oldstable"},
+			expectedError:   nil,
+		},
+		{
+			testTitle:       "should return an error when the name contains invalid characters",
+			name:            "my work",
+			version:         "1.21.6",
+			expectedAliases: map[string]string{"legacy": "1.19"},
+			expectedError:   errors.New(`invalid alias name "my work", it should start with a letter and contain only letters, numbers, '-' and '_'`),
+		},
+		{
+			testTitle:       "should return an error when the name is a keyword",
+			name:            "latest",
+			version:         "1.21.6",
+			expectedAliases: map[string]string{"legacy": "1.19"},
+			expectedError:   errors.New(`invalid alias name "latest", it can't be a version or a keyword`),
+		},
+		{
+			testTitle:       "should return an error when the name is a version",
+			name:            "go1",
+			version:         "1.21.6",
+			expectedAliases: map[string]string{"legacy": "1.19"},
+			expectedError:   errors.New(`invalid alias name "go1", it can't be a version or a keyword`),
+		},
+		{
+			testTitle:       "should return an error when the version is not valid",
+			name:            "work",
+			version:         "1.21.x",
+			expectedAliases: map[string]string{"legacy": "1.19"},
+			expectedError:   errors.New("invalid Go version"),
+		},
+		{
+			testTitle:       "should return an error when the aliases can't be stored",
+			name:            "work",
+			version:         "1.21.6",
+			storeError:      errors.New("an error occurred while writing to the file"),
+			expectedAliases: map[string]string{"legacy": "1.19"},
+			expectedError:   errors.New("an error occurred while writing to the file"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{
+				Aliases:           map[string]string{"legacy": "1.19"},
+				StoreAliasesError: tc.storeError,
+			}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			err := versioner.SetAlias(tc.name, tc.version)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(fileHelpers.Aliases, tc.expectedAliases) {
+				t.Errorf("Wrong aliases stored, got=%s", cmp.Diff(fileHelpers.Aliases, tc.expectedAliases))
+			}
+		})
+	}
+}
+
+func TestDeleteAlias(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		name            string
+		expectedAliases map[string]string
+		expectedError   error
+	}{
+		{
+			testTitle:       "should delete the alias",
+			name:            "work",
+			expectedAliases: map[string]string{"legacy": "1.19"},
+			expectedError:   nil,
+		},
+		{
+			testTitle:       "should return an error when the alias does not exist",
+			name:            "home",
+			expectedAliases: map[string]string{"legacy": "1.19", "work": "1.21.6"},
+			expectedError:   errors.New(`alias "home" does not exist`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{Aliases: map[string]string{"legacy": "1.19", "work": "1.21.6"}}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			err := versioner.DeleteAlias(tc.name)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(fileHelpers.Aliases, tc.expectedAliases) {
+				t.Errorf("Wrong aliases stored, got=%s", cmp.Diff(fileHelpers.Aliases, tc.expectedAliases))
+			}
+		})
+	}
+}
+
+func TestGetAliasesSortedByName(t *testing.T) {
+	fileHelpers := &testutils.FakeFilesHelper{Aliases: map[string]string{"work": "1.21.6", "legacy": "1.19"}}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	aliases, err := versioner.GetAliases()
+	if err != nil {
+		t.Fatalf("error should be nil, instead got %q", err.Error())
+	}
+
+	expectedAliases := []version.Alias{{Name: "legacy", Version: "1.19"}, {Name: "work", Version: "1.21.6"}}
+	if !cmp.Equal(aliases, expectedAliases) {
+		t.Errorf("Wrong aliases received, got=%s", cmp.Diff(aliases, expectedAliases))
+	}
+}
+
+func TestGetAliasesForVersion(t *testing.T) {
+	versions := getConstraintVersions()
+
+	fileHelpers := &testutils.FakeFilesHelper{Aliases: map[string]string{"work": "1.21.5", "legacy": "1.20", "recent": "~1.21", "old": "oldstable"}}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	names, err := versioner.GetAliasesForVersion(versions, versions[3])
+	if err != nil {
+		t.Fatalf("error should be nil, instead got %q", err.Error())
+	}
+
+	expectedNames := []string{"old", "recent", "work"}
+	if !cmp.Equal(names, expectedNames) {
+		t.Errorf("Wrong aliases received, got=%s", cmp.Diff(names, expectedNames))
+	}
+}
+
+func TestResolveAlias(t *testing.T) {
+	versions := getConstraintVersions()
+
+	fileHelpers := &testutils.FakeFilesHelper{Aliases: map[string]string{"work": "1.21.4", "legacy": "1.20"}}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	res, err := versioner.Resolve(versions, "legacy", false)
+	if err != nil {
+		t.Fatalf("error should be nil, instead got %q", err.Error())
+	}
+
+	if !cmp.Equal(res, versions[6]) {
+		t.Errorf("Wrong version received, got=%s", cmp.Diff(res, versions[6]))
+	}
+}
@@ -0,0 +1,85 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PackageAPI contains the features of the exported API of a standard library package.
+type PackageAPI struct {
+	// Package is the import path of the package (e.g. `bytes`), followed by the platform
+	// for the features that exist only on specific platforms (e.g. `syscall (linux-386)`).
+	Package string `json:"package"`
+
+	// Features contains the exported features of the package (e.g. `func Clone([]uint8) []uint8`), sorted alphabetically.
+	Features []string `json:"features"`
+}
+
+// DiffAPI returns the features of the standard library API that exist in the new version but not in the old one,
+// grouped by package and sorted by the import path of the package. Both versions must be installed, since the API
+// is read from the `api` directory of their GOROOT.
+//
+// If the old version is not older than the new version, or the API of any of the versions can't be read,
+// DiffAPI returns back an error.
+func (v Version) DiffAPI(oldVersion *ExtendedVersion, newVersion *ExtendedVersion) ([]PackageAPI, error) {
+	oldSemver, err := oldVersion.getSemver()
+	if err != nil {
+		return nil, err
+	}
+
+	newSemver, err := newVersion.getSemver()
+	if err != nil {
+		return nil, err
+	}
+
+	if oldSemver.Compare(*newSemver) >= 0 {
+		return nil, fmt.Errorf("the old version %s must be older than the new version %s", oldSemver.GetVersion(), newSemver.GetVersion())
+	}
+
+	oldFeatures, err := v.fileHelpers.GetAPIFeatures(oldVersion.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	newFeatures, err := v.fileHelpers.GetAPIFeatures(newVersion.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(oldFeatures))
+	for _, feature := range oldFeatures {
+		existing[feature] = true
+	}
+
+	added := map[string][]string{}
+	for _, feature := range newFeatures {
+		if existing[feature] {
+			continue
+		}
+
+		// the same feature can exist in multiple files of the same version.
+		existing[feature] = true
+
+		pkg, api, ok := strings.Cut(strings.TrimPrefix(feature, "pkg "), ", ")
+		if !ok {
+			continue
+		}
+
+		added[pkg] = append(added[pkg], api)
+	}
+
+	packages := make([]PackageAPI, 0, len(added))
+	for pkg, features := range added {
+		sort.Strings(features)
+		packages = append(packages, PackageAPI{Package: pkg, Features: features})
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Package < packages[j].Package
+	})
+
+	return packages, nil
+}
@@ -0,0 +1,115 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/api_client"
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffAPI(t *testing.T) {
+	testCases := []struct {
+		testTitle           string
+		apiFeatures         map[string][]string
+		getAPIFeaturesError error
+		oldVersion          string
+		newVersion          string
+		expectedPackages    []version.PackageAPI
+		expectedError       error
+	}{
+		{
+			testTitle: "should return the added features grouped by package",
+			apiFeatures: map[string][]string{
+				"go1.20.14": {
+					"pkg bytes, func Clone([]uint8) []uint8",
+					"pkg errors, func Join(...error) error",
+				},
+				"go1.21.5": {
+					"pkg bytes, func Clone([]uint8) []uint8",
+					"pkg errors, func Join(...error) error",
+					"pkg slices, func Sort[$0 interface{ ~[]$1 }, $1 cmp.Ordered]($0)",
+					"pkg log/slog, func Info(string, ...interface{})",
+					"pkg slices, func Max[$0 interface{ ~[]$1 }, $1 cmp.Ordered]($0) $1",
+					"pkg slices, func Max[$0 interface{ ~[]$1 }, $1 cmp.Ordered]($0) $1",
+					"pkg syscall (linux-386), const SYS_X = 1",
+					"invalid feature",
+				},
+			},
+			expectedPackages: []version.PackageAPI{
+				{Package: "log/slog", Features: []string{"func Info(string, ...interface{})"}},
+				{Package: "slices", Features: []string{
+					"func Max[$0 interface{ ~[]$1 }, $1 cmp.Ordered]($0) $1",
+					"func Sort[$0 interface{ ~[]$1 }, $1 cmp.Ordered]($0)",
+				}},
+				{Package: "syscall (linux-386)", Features: []string{"const SYS_X = 1"}},
+			},
+		},
+		{
+			testTitle: "should return no packages when no features are added",
+			apiFeatures: map[string][]string{
+				"go1.20.14": {"pkg bytes, func Clone([]uint8) []uint8"},
+				"go1.21.5":  {"pkg bytes, func Clone([]uint8) []uint8"},
+			},
+			expectedPackages: []version.PackageAPI{},
+		},
+		{
+			testTitle:     "should return an error when the old version is newer than the new version",
+			oldVersion:    "go1.21.5",
+			newVersion:    "go1.20.14",
+			expectedError: errors.New("the old version 1.21.5 must be older than the new version 1.20.14"),
+		},
+		{
+			testTitle:     "should return an error when the versions are the same",
+			oldVersion:    "go1.21.5",
+			newVersion:    "go1.21.5",
+			expectedError: errors.New("the old version 1.21.5 must be older than the new version 1.21.5"),
+		},
+		{
+			testTitle:           "should return an error when the API can't be read",
+			getAPIFeaturesError: errors.New("an error occurred while reading the API"),
+			expectedError:       errors.New("an error occurred while reading the API"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{
+				APIFeatures:         tc.apiFeatures,
+				GetAPIFeaturesError: tc.getAPIFeaturesError,
+			}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			oldName, newName := "go1.20.14", "go1.21.5"
+			if tc.oldVersion != "" {
+				oldName, newName = tc.oldVersion, tc.newVersion
+			}
+
+			oldVersion := &version.ExtendedVersion{VersionInfo: api_client.VersionInfo{Version: oldName}}
+			newVersion := &version.ExtendedVersion{VersionInfo: api_client.VersionInfo{Version: newName}}
+
+			packages, err := versioner.DiffAPI(oldVersion, newVersion)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(packages, tc.expectedPackages) {
+				t.Errorf("Wrong packages received, got=%s", cmp.Diff(packages, tc.expectedPackages))
+			}
+		})
+	}
+}
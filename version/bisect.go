@@ -0,0 +1,172 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import "fmt"
+
+// BisectStatus is the result of the command for a version that is tested during a bisect.
+type BisectStatus int
+
+const (
+	// BisectGood means that the version doesn't have the regression.
+	BisectGood BisectStatus = iota
+
+	// BisectBad means that the version has the regression.
+	BisectBad
+
+	// BisectSkip means that the version can't be tested (e.g. it can't be downloaded, or the command
+	// can't decide), so it's excluded from the search.
+	BisectSkip
+)
+
+// String returns the name of the status, which is printed to the cli.
+func (s BisectStatus) String() string {
+	switch s {
+	case BisectGood:
+		return "good"
+	case BisectBad:
+		return "bad"
+	default:
+		return "skipped"
+	}
+}
+
+// Bisect contains the state of a binary search over the releases between a good and a bad version,
+// which is used to find the first release that introduced a regression.
+type Bisect struct {
+	// versions contains the releases of the search, sorted from the oldest to the newest.
+	// The first one is the good version and the last one is the bad version.
+	versions []*ExtendedVersion
+
+	// good is the index of the newest version that is known to be good.
+	good int
+
+	// bad is the index of the oldest version that is known to be bad.
+	bad int
+
+	// skipped contains the indexes of the versions that are skipped.
+	skipped map[int]bool
+}
+
+// NewBisect returns a new Bisect for the given versions, which must be sorted from the oldest to the newest,
+// where the first version is good and the last one is bad (see FindBisectVersions).
+func NewBisect(versions []*ExtendedVersion) *Bisect {
+	return &Bisect{
+		versions: versions,
+		good:     0,
+		bad:      len(versions) - 1,
+		skipped:  map[int]bool{},
+	}
+}
+
+// Next returns the next version that has to be tested, which is the version in the middle of the remaining ones.
+// If the version in the middle is skipped, the closest version to it that is not skipped is returned instead.
+//
+// If there are no more versions to test, Next returns back nil.
+func (b *Bisect) Next() *ExtendedVersion {
+	middle := b.good + (b.bad-b.good)/2
+
+	for distance := 0; middle-distance > b.good || middle+distance < b.bad; distance++ {
+		for _, i := range []int{middle - distance, middle + distance} {
+			if i > b.good && i < b.bad && !b.skipped[i] {
+				return b.versions[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// Mark stores the status of the given version, and narrows down the remaining versions.
+func (b *Bisect) Mark(ev *ExtendedVersion, status BisectStatus) {
+	for i := b.good + 1; i < b.bad; i++ {
+		if b.versions[i] != ev {
+			continue
+		}
+
+		switch status {
+		case BisectGood:
+			b.good = i
+		case BisectBad:
+			b.bad = i
+		default:
+			b.skipped[i] = true
+		}
+
+		return
+	}
+}
+
+// Remaining returns the count of the versions that are not tested yet and not skipped.
+func (b *Bisect) Remaining() int {
+	remaining := 0
+	for i := b.good + 1; i < b.bad; i++ {
+		if !b.skipped[i] {
+			remaining++
+		}
+	}
+
+	return remaining
+}
+
+// FirstBad returns the versions that can be the first bad version, sorted from the oldest to the newest.
+// When the search is complete (Next returns nil), that's the first bad version, together with
+// the skipped versions before it, which can't be ruled out.
+func (b *Bisect) FirstBad() []*ExtendedVersion {
+	versions := []*ExtendedVersion{}
+	for i := b.good + 1; i < b.bad; i++ {
+		if b.skipped[i] {
+			versions = append(versions, b.versions[i])
+		}
+	}
+
+	return append(versions, b.versions[b.bad])
+}
+
+// FindBisectVersions returns the releases from the good version to the bad version (including both), sorted from
+// the oldest to the newest, which can be passed to NewBisect. The good and the bad versions are resolved with Resolve,
+// and the pre-releases between them are included only if includePrerelease is true.
+//
+// If any of the versions can't be resolved, or if the good version is not older than the bad version,
+// FindBisectVersions returns back an error.
+func (v Version) FindBisectVersions(evs []*ExtendedVersion, good string, bad string, includePrerelease bool) ([]*ExtendedVersion, error) {
+	goodVersion, err := v.Resolve(evs, good, includePrerelease)
+	if err != nil {
+		return nil, err
+	}
+
+	badVersion, err := v.Resolve(evs, bad, includePrerelease)
+	if err != nil {
+		return nil, err
+	}
+
+	goodSemver, err := goodVersion.getSemver()
+	if err != nil {
+		return nil, err
+	}
+
+	badSemver, err := badVersion.getSemver()
+	if err != nil {
+		return nil, err
+	}
+
+	if goodSemver.Compare(*badSemver) >= 0 {
+		return nil, fmt.Errorf("the good version %s must be older than the bad version %s", goodSemver.GetVersion(), badSemver.GetVersion())
+	}
+
+	versions := []*ExtendedVersion{goodVersion}
+
+	// evs are sorted from the newest to the oldest, so they are iterated backwards.
+	for i := len(evs) - 1; i >= 0; i-- {
+		semver, err := evs[i].getSemver()
+		if err != nil || (semver.IsPrerelease() && !includePrerelease) {
+			continue
+		}
+
+		if semver.Compare(*goodSemver) > 0 && semver.Compare(*badSemver) < 0 {
+			versions = append(versions, evs[i])
+		}
+	}
+
+	return append(versions, badVersion), nil
+}
@@ -0,0 +1,167 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/api_client"
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindBisectVersions(t *testing.T) {
+	testCases := []struct {
+		testTitle         string
+		good              string
+		bad               string
+		includePrerelease bool
+		expectedNames     []string
+		expectedError     error
+	}{
+		{
+			testTitle:     "should return the versions from the good to the bad version",
+			good:          "1.20.12",
+			bad:           "1.22.0",
+			expectedNames: []string{"go1.20.12", "go1.21.3", "go1.21.4", "go1.21.5", "go1.22.0"},
+		},
+		{
+			testTitle:     "should resolve the good and the bad versions",
+			good:          "1.21",
+			bad:           "latest",
+			expectedNames: []string{"go1.21.5", "go1.22.0", "go1.22.1"},
+		},
+		{
+			testTitle:         "should include the pre-releases when includePrerelease is true",
+			good:              "1.22.0",
+			bad:               "1.23rc1",
+			includePrerelease: true,
+			expectedNames:     []string{"go1.22.0", "go1.22.1", "go1.23rc1"},
+		},
+		{
+			testTitle:     "should return an error when the good version is not older than the bad version",
+			good:          "1.22.0",
+			bad:           "1.21.5",
+			expectedError: errors.New("the good version 1.22.0 must be older than the bad version 1.21.5"),
+		},
+		{
+			testTitle:     "should return an error when a version can't be resolved",
+			good:          "1.19",
+			bad:           "1.21.5",
+			expectedError: errors.New("1.19 is not a valid version, the closest versions are: 1.20.12"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			versions, err := versioner.FindBisectVersions(getConstraintVersions(), tc.good, tc.bad, tc.includePrerelease)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if tc.expectedError == nil && !cmp.Equal(getVersionNames(versions), tc.expectedNames) {
+				t.Errorf("Wrong versions received, got=%s", cmp.Diff(getVersionNames(versions), tc.expectedNames))
+			}
+		})
+	}
+}
+
+func TestBisect(t *testing.T) {
+	testCases := []struct {
+		testTitle        string
+		firstBad         int
+		skipped          map[int]bool
+		expectedTested   []string
+		expectedFirstBad []string
+	}{
+		{
+			testTitle:        "should find the first bad version",
+			firstBad:         4,
+			expectedTested:   []string{"go1.21.5", "go1.22.0"},
+			expectedFirstBad: []string{"go1.22.0"},
+		},
+		{
+			testTitle:        "should find the first bad version when it's the bad version",
+			firstBad:         6,
+			expectedTested:   []string{"go1.21.5", "go1.22.0", "go1.22.1"},
+			expectedFirstBad: []string{"go1.23.0"},
+		},
+		{
+			testTitle:        "should test the closest version when the middle one is skipped",
+			firstBad:         1,
+			skipped:          map[int]bool{3: true},
+			expectedTested:   []string{"go1.21.5", "go1.21.4", "go1.21.3"},
+			expectedFirstBad: []string{"go1.21.3"},
+		},
+		{
+			testTitle:        "should return the skipped versions that can be the first bad version",
+			firstBad:         4,
+			skipped:          map[int]bool{4: true},
+			expectedTested:   []string{"go1.21.5", "go1.22.0", "go1.22.1"},
+			expectedFirstBad: []string{"go1.22.0", "go1.22.1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			names := []string{"go1.20.12", "go1.21.3", "go1.21.4", "go1.21.5", "go1.22.0", "go1.22.1", "go1.23.0"}
+
+			versions := make([]*version.ExtendedVersion, 0, len(names))
+			for _, name := range names {
+				versions = append(versions, &version.ExtendedVersion{
+					VersionInfo: api_client.VersionInfo{Version: name, IsStable: true},
+				})
+			}
+
+			bisect := version.NewBisect(versions)
+
+			tested := []string{}
+			for ev := bisect.Next(); ev != nil; ev = bisect.Next() {
+				tested = append(tested, ev.Version)
+
+				index := 0
+				for i := range versions {
+					if versions[i] == ev {
+						index = i
+					}
+				}
+
+				switch {
+				case tc.skipped[index]:
+					bisect.Mark(ev, version.BisectSkip)
+				case index >= tc.firstBad:
+					bisect.Mark(ev, version.BisectBad)
+				default:
+					bisect.Mark(ev, version.BisectGood)
+				}
+			}
+
+			if !cmp.Equal(tested, tc.expectedTested) {
+				t.Errorf("Wrong versions tested, got=%s", cmp.Diff(tested, tc.expectedTested))
+			}
+
+			if !cmp.Equal(getVersionNames(bisect.FirstBad()), tc.expectedFirstBad) {
+				t.Errorf("Wrong first bad versions received, got=%s", cmp.Diff(getVersionNames(bisect.FirstBad()), tc.expectedFirstBad))
+			}
+
+			if bisect.Remaining() != 0 {
+				t.Errorf("remaining versions should be 0, instead got %d", bisect.Remaining())
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// comparisonRegex describes a single comparison of a constraint (e.g. `>=1.21.4`).
+var comparisonRegex = regexp.MustCompile(`^(=|!=|>=|<=|>|<|~|\^)?(\S+)$`)
+
+// operatorSpaceRegex matches the whitespace between an operator and a version (e.g. `>= 1.21`).
+var operatorSpaceRegex = regexp.MustCompile(`(=|!=|>=|<=|>|<|~|\^)\s+`)
+
+// comparison contains an operator and the version the operator is applied to.
+type comparison struct {
+	// operator is one of `=`, `!=`, `>`, `>=`, `<`, `<=`, `~` or `^`.
+	operator string
+
+	// version is the (possibly partial) version of the comparison.
+	version Semver
+}
+
+// check returns if the given version satisfies the comparison.
+//
+// The partial versions describe a whole release line, so for example:
+//   - `>1.21` is satisfied by versions newer than any 1.21 version.
+//   - `<=1.21` is satisfied by every 1.21 version and the older ones.
+//   - `~1.21.4` is satisfied by 1.21.4 and newer 1.21 versions.
+//   - `^1.21.4` is satisfied by 1.21.4 and newer 1.x versions.
+func (c comparison) check(version Semver) bool {
+	compared := version.Compare(c.version)
+	included := c.version.Includes(version)
+
+	switch c.operator {
+	case "!=":
+		return !included
+	case ">":
+		return compared > 0 && !included
+	case ">=":
+		return compared >= 0 || included
+	case "<":
+		return compared < 0 && !included
+	case "<=":
+		return compared <= 0 || included
+	case "~":
+		line := Semver{Major: c.version.Major, Minor: c.version.Minor}
+		return line.Includes(version) && (compared >= 0 || included)
+	case "^":
+		line := Semver{Major: c.version.Major}
+		return line.Includes(version) && (compared >= 0 || included)
+	default:
+		return included
+	}
+}
+
+// Constraint contains a parsed version constraint expression (e.g. `>=1.21.4 <1.23`).
+type Constraint struct {
+	// alternatives contains the groups of comparisons that are separated by `||`.
+	// A version satisfies the constraint if it satisfies all the comparisons of any group.
+	alternatives [][]comparison
+
+	// expression is the constraint as it was given.
+	expression string
+}
+
+// Check returns if the given version satisfies the constraint.
+func (c Constraint) Check(version Semver) bool {
+	for _, comparisons := range c.alternatives {
+		satisfied := true
+
+		for _, comparison := range comparisons {
+			if !comparison.check(version) {
+				satisfied = false
+				break
+			}
+		}
+
+		if satisfied {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasPrerelease returns if any of the versions in the constraint is a beta or a release candidate.
+func (c Constraint) HasPrerelease() bool {
+	for _, comparisons := range c.alternatives {
+		for _, comparison := range comparisons {
+			if comparison.version.IsPrerelease() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// String returns the constraint expression as it was given.
+func (c Constraint) String() string {
+	return c.expression
+}
+
+// IsConstraint returns if the given text is a constraint expression rather than a single version,
+// by looking for operators and separators.
+func IsConstraint(text string) bool {
+	return strings.ContainsAny(strings.TrimSpace(text), "=!<>~^|, \t")
+}
+
+// ParseConstraint parses the given constraint expression.
+//
+// The expression contains comparisons, where each comparison is an optional operator followed by a version
+// (e.g. `>=1.21.4`, `~1.21`, `^1`, `!=1.22.0`). A comparison without an operator is the same as `=`.
+// The supported operators are:
+//   - `=` and `!=`: the version is (or is not) part of the given version line (`=1.21` matches any 1.21 version).
+//   - `>`, `>=`, `<` and `<=`: the version is newer or older than the given version.
+//   - `~`: the version is at least the given version, within the same minor version (`~1.21.4` means `>=1.21.4 <1.22`).
+//   - `^`: the version is at least the given version, within the same major version (`^1.21` means `>=1.21 <2`).
+//
+// Comparisons separated by whitespace or commas must all be satisfied, while groups separated by `||`
+// are alternatives (e.g. `~1.20 || >=1.22`).
+//
+// If the parse is successful it will store it in the value pointed to by constraint.
+//
+// If the parse fails, ParseConstraint will return an error.
+func ParseConstraint(expression string, constraint *Constraint) error {
+	var alternatives [][]comparison
+
+	for _, group := range strings.Split(expression, "||") {
+		var comparisons []comparison
+
+		// allow whitespace between the operator and the version (e.g. `>= 1.21`)
+		group = operatorSpaceRegex.ReplaceAllString(group, "$1")
+
+		for _, part := range strings.FieldsFunc(group, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		}) {
+			groups := comparisonRegex.FindStringSubmatch(part)
+			if groups == nil {
+				return fmt.Errorf("invalid constraint %q", part)
+			}
+
+			semver := Semver{}
+			if err := ParseSemver(groups[2], &semver); err != nil {
+				return fmt.Errorf("invalid version %q in constraint %q", groups[2], part)
+			}
+
+			comparisons = append(comparisons, comparison{operator: groups[1], version: semver})
+		}
+
+		if len(comparisons) == 0 {
+			return fmt.Errorf("invalid constraint %q", expression)
+		}
+
+		alternatives = append(alternatives, comparisons)
+	}
+
+	constraint.alternatives = alternatives
+	constraint.expression = expression
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package version_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/version"
+)
+
+func TestParseConstraint(t *testing.T) {
+	testCases := []struct {
+		testTitle     string
+		expression    string
+		expectedError error
+	}{
+		{
+			testTitle:     "should parse a single comparison",
+			expression:    ">=1.21.4",
+			expectedError: nil,
+		},
+		{
+			testTitle:     "should parse comparisons separated by whitespace",
+			expression:    ">=1.21.4 <1.23",
+			expectedError: nil,
+		},
+		{
+			testTitle:     "should parse comparisons separated by commas",
+			expression:    ">=1.21.4, <1.23",
+			expectedError: nil,
+		},
+		{
+			testTitle:     "should parse alternatives",
+			expression:    "~1.20 || ^1.22",
+			expectedError: nil,
+		},
+		{
+			testTitle:     "should parse whitespace between the operator and the version",
+			expression:    ">= 1.21.4 != 1.22.0",
+			expectedError: nil,
+		},
+		{
+			testTitle:     "should return an error when the operator is unknown",
+			expression:    "=>1.21",
+			expectedError: errors.New(`invalid version ">1.21" in constraint "=>1.21"`),
+		},
+		{
+			testTitle:     "should return an error when the version is not valid",
+			expression:    ">=1.21.x",
+			expectedError: errors.New(`invalid version "1.21.x" in constraint ">=1.21.x"`),
+		},
+		{
+			testTitle:     "should return an error when an alternative is empty",
+			expression:    ">=1.21 ||",
+			expectedError: errors.New(`invalid constraint ">=1.21 ||"`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			constraint := &version.Constraint{}
+			err := version.ParseConstraint(tc.expression, constraint)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if tc.expectedError == nil && constraint.String() != tc.expression {
+				t.Errorf("expression should be %q, instead got %q", tc.expression, constraint.String())
+			}
+		})
+	}
+}
+
+func TestConstraintCheck(t *testing.T) {
+	testCases := []struct {
+		expression string
+		version    string
+		expected   bool
+	}{
+		{expression: "1.21", version: "1.21.3", expected: true},
+		{expression: "=1.21", version: "1.22.0", expected: false},
+		{expression: "1.21.3", version: "1.21.3", expected: true},
+		{expression: "1.21.3", version: "1.21.4", expected: false},
+		{expression: "!=1.22.0", version: "1.22.0", expected: false},
+		{expression: "!=1.22.0", version: "1.22.1", expected: true},
+		{expression: "!=1.22", version: "1.22.1", expected: false},
+		{expression: ">1.21", version: "1.21.9", expected: false},
+		{expression: ">1.21", version: "1.22.0", expected: true},
+		{expression: ">1.21.3", version: "1.21.4", expected: true},
+		{expression: ">=1.21.4", version: "1.21.4", expected: true},
+		{expression: ">=1.21.4", version: "1.21.3", expected: false},
+		{expression: ">=1.21", version: "1.21.0", expected: true},
+		{expression: "<1.23", version: "1.22.9", expected: true},
+		{expression: "<1.23", version: "1.23.0", expected: false},
+		{expression: "<=1.22", version: "1.22.9", expected: true},
+		{expression: "<=1.22", version: "1.23.0", expected: false},
+		{expression: "~1.21", version: "1.21.9", expected: true},
+		{expression: "~1.21", version: "1.22.0", expected: false},
+		{expression: "~1.21.4", version: "1.21.3", expected: false},
+		{expression: "~1.21.4", version: "1.21.5", expected: true},
+		{expression: "^1", version: "1.22.0", expected: true},
+		{expression: "^1.21", version: "1.20.5", expected: false},
+		{expression: "^1.21", version: "2.0.0", expected: false},
+		{expression: ">=1.21.4 <1.23", version: "1.22.5", expected: true},
+		{expression: ">=1.21.4 <1.23", version: "1.23.0", expected: false},
+		{expression: ">=1.21.4, <1.23", version: "1.21.3", expected: false},
+		{expression: "~1.20 || >=1.22", version: "1.20.5", expected: true},
+		{expression: "~1.20 || >=1.22", version: "1.21.5", expected: false},
+		{expression: "~1.20 || >=1.22", version: "1.22.0", expected: true},
+		{expression: ">=1.22rc1", version: "1.22rc2", expected: true},
+		{expression: ">=1.22rc1", version: "1.22beta1", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s with %s", tc.expression, tc.version), func(t *testing.T) {
+			constraint := &version.Constraint{}
+			if err := version.ParseConstraint(tc.expression, constraint); err != nil {
+				t.Fatalf("error should be nil, instead got %q", err.Error())
+			}
+
+			semver := &version.Semver{}
+			if err := version.ParseSemver(tc.version, semver); err != nil {
+				t.Fatalf("error should be nil, instead got %q", err.Error())
+			}
+
+			if res := constraint.Check(*semver); res != tc.expected {
+				t.Errorf("result should be %t, instead got %t", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestConstraintHasPrerelease(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   bool
+	}{
+		{expression: ">=1.21.4 <1.23", expected: false},
+		{expression: ">=1.22rc1", expected: true},
+		{expression: "~1.20 || >=1.22beta1", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			constraint := &version.Constraint{}
+			if err := version.ParseConstraint(tc.expression, constraint); err != nil {
+				t.Fatalf("error should be nil, instead got %q", err.Error())
+			}
+
+			if res := constraint.HasPrerelease(); res != tc.expected {
+				t.Errorf("result should be %t, instead got %t", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestIsConstraint(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected bool
+	}{
+		{text: "1.21.3", expected: false},
+		{text: "go1.21rc2", expected: false},
+		{text: ">=1.21.4", expected: true},
+		{text: "~1.21", expected: true},
+		{text: "1.20 || 1.21", expected: true},
+		{text: "1.20, 1.21", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			if res := version.IsConstraint(tc.text); res != tc.expected {
+				t.Errorf("result should be %t, instead got %t", tc.expected, res)
+			}
+		})
+	}
+}
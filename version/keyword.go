@@ -0,0 +1,150 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/VassilisPallas/gvs/errors"
+)
+
+const (
+	// KeywordLatest describes the newest stable version.
+	KeywordLatest = "latest"
+
+	// KeywordStable describes the newest stable version, same as KeywordLatest.
+	KeywordStable = "stable"
+
+	// KeywordOldStable describes the newest stable version of the previous release line
+	// (e.g. `1.21.5` when the newest stable version is `1.22.0`).
+	KeywordOldStable = "oldstable"
+
+	// KeywordPrevious describes the version that was used before the current one.
+	KeywordPrevious = "previous"
+
+	// KeywordCurrentMinor describes the newest stable patch version of the currently used minor version.
+	KeywordCurrentMinor = "current-minor"
+
+	// KeywordLatestRC describes the newest release candidate.
+	KeywordLatestRC = "latest-rc"
+)
+
+// keywords contains all the supported keywords.
+var keywords = []string{KeywordLatest, KeywordStable, KeywordOldStable, KeywordPrevious, KeywordCurrentMinor, KeywordLatestRC}
+
+// IsKeyword returns if the given text is one of the symbolic version names
+// (`latest`, `stable`, `oldstable`, `previous`, `current-minor` or `latest-rc`).
+func IsKeyword(text string) bool {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	for _, keyword := range keywords {
+		if keyword == text {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findNewestVersion returns the newest version for which the match function returns true, or nil if there is none.
+func findNewestVersion(evs []*ExtendedVersion, match func(semver *Semver) bool) *ExtendedVersion {
+	var found *ExtendedVersion
+	var foundSemver *Semver
+
+	for _, ev := range evs {
+		semver, err := ev.getSemver()
+		if err != nil || !match(semver) {
+			continue
+		}
+
+		if found == nil || semver.Compare(*foundSemver) > 0 {
+			found = ev
+			foundSemver = semver
+		}
+	}
+
+	return found
+}
+
+// getReleaseLine returns the major and the minor version of the given version, which describes the whole release line.
+func getReleaseLine(semver *Semver) Semver {
+	return Semver{Major: semver.Major, Minor: semver.Minor}
+}
+
+// FindVersionBasedOnKeyword returns the version that is described from the given keyword.
+//
+// The keywords are computed from the given versions and the state of the installed versions:
+//   - `latest` and `stable` return the newest stable version.
+//   - `oldstable` returns the newest stable version of the release line before the newest one.
+//   - `previous` returns the version that was used before the current one.
+//   - `current-minor` returns the newest stable patch version of the currently used minor version.
+//   - `latest-rc` returns the newest release candidate.
+//
+// If the keyword is not supported or no version is found, FindVersionBasedOnKeyword returns back an error.
+func (v Version) FindVersionBasedOnKeyword(evs []*ExtendedVersion, keyword string) (*ExtendedVersion, error) {
+	isStable := func(semver *Semver) bool {
+		return !semver.IsPrerelease()
+	}
+
+	var found *ExtendedVersion
+
+	switch strings.ToLower(strings.TrimSpace(keyword)) {
+	case KeywordLatest, KeywordStable:
+		found = findNewestVersion(evs, isStable)
+	case KeywordOldStable:
+		latest := findNewestVersion(evs, isStable)
+		if latest == nil {
+			break
+		}
+
+		latestSemver, _ := latest.getSemver()
+		latestLine := getReleaseLine(latestSemver)
+
+		found = findNewestVersion(evs, func(semver *Semver) bool {
+			return isStable(semver) && !latestLine.Includes(*semver)
+		})
+	case KeywordPrevious:
+		previousVersion := v.fileHelpers.GetPreviousVersion()
+		if previousVersion == "" {
+			return nil, fmt.Errorf("there is no previous version")
+		}
+
+		// the previous version is the exact version that was used, so `go1.20` is not the newest 1.20 version.
+		for _, ev := range evs {
+			if ev.Version == previousVersion {
+				return ev, nil
+			}
+		}
+
+		return nil, fmt.Errorf("the previous version %s is not found", strings.TrimPrefix(previousVersion, "go"))
+	case KeywordCurrentMinor:
+		currentVersion := v.GetCurrentVersion()
+		if currentVersion == "" {
+			return nil, &errors.NoInstalledVersionsError{}
+		}
+
+		currentSemver := &Semver{}
+		if err := ParseSemver(currentVersion, currentSemver); err != nil {
+			return nil, err
+		}
+
+		currentLine := getReleaseLine(currentSemver)
+
+		found = findNewestVersion(evs, func(semver *Semver) bool {
+			return isStable(semver) && currentLine.Includes(*semver)
+		})
+	case KeywordLatestRC:
+		found = findNewestVersion(evs, func(semver *Semver) bool {
+			return semver.ReleaseCandidate != nil
+		})
+	default:
+		return nil, fmt.Errorf("%q is not a supported keyword", keyword)
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("no version found for %q", keyword)
+	}
+
+	return found, nil
+}
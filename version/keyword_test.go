@@ -0,0 +1,179 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/api_client"
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsKeyword(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected bool
+	}{
+		{text: "latest", expected: true},
+		{text: "stable", expected: true},
+		{text: "oldstable", expected: true},
+		{text: "previous", expected: true},
+		{text: "current-minor", expected: true},
+		{text: "latest-rc", expected: true},
+		{text: "Latest", expected: true},
+		{text: "1.21", expected: false},
+		{text: "newest", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			if res := version.IsKeyword(tc.text); res != tc.expected {
+				t.Errorf("result should be %t, instead got %t", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestFindVersionBasedOnKeyword(t *testing.T) {
+	versions := getConstraintVersions()
+
+	releaseVersions := []*version.ExtendedVersion{
+		{VersionInfo: api_client.VersionInfo{Version: "go1.20.14", IsStable: true}},
+		{VersionInfo: api_client.VersionInfo{Version: "go1.20", IsStable: true}},
+	}
+
+	testCases := []struct {
+		testTitle         string
+		keyword           string
+		versions          []*version.ExtendedVersion
+		recentVersion     string
+		previousVersion   string
+		expecectedVersion *version.ExtendedVersion
+		expectedError     error
+	}{
+		{
+			testTitle:         "should return the newest stable version for latest",
+			keyword:           "latest",
+			versions:          versions,
+			expecectedVersion: versions[1],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should return the newest stable version for stable",
+			keyword:           "stable",
+			versions:          versions,
+			expecectedVersion: versions[1],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should return the newest version of the previous release line for oldstable",
+			keyword:           "oldstable",
+			versions:          versions,
+			expecectedVersion: versions[3],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should return an error for oldstable when there is only one release line",
+			keyword:           "oldstable",
+			versions:          versions[1:3],
+			expecectedVersion: nil,
+			expectedError:     errors.New(`no version found for "oldstable"`),
+		},
+		{
+			testTitle:         "should return the previously used version for previous",
+			keyword:           "previous",
+			versions:          versions,
+			previousVersion:   "go1.21.4",
+			expecectedVersion: versions[4],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should return the exact previously used version for previous, instead of the newest patch version",
+			keyword:           "previous",
+			versions:          releaseVersions,
+			previousVersion:   "go1.20",
+			expecectedVersion: releaseVersions[1],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should return an error for previous when the previous version is not found",
+			keyword:           "previous",
+			versions:          releaseVersions[:1],
+			previousVersion:   "go1.20",
+			expecectedVersion: nil,
+			expectedError:     errors.New("the previous version 1.20 is not found"),
+		},
+		{
+			testTitle:         "should return an error for previous when there is no previous version",
+			keyword:           "previous",
+			versions:          versions,
+			expecectedVersion: nil,
+			expectedError:     errors.New("there is no previous version"),
+		},
+		{
+			testTitle:         "should return the newest patch version of the current minor version for current-minor",
+			keyword:           "current-minor",
+			versions:          versions,
+			recentVersion:     "go1.21.3",
+			expecectedVersion: versions[3],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should return an error for current-minor when there is no current version",
+			keyword:           "current-minor",
+			versions:          versions,
+			expecectedVersion: nil,
+			expectedError:     errors.New("there is no any installed version"),
+		},
+		{
+			testTitle:         "should return the newest release candidate for latest-rc",
+			keyword:           "latest-rc",
+			versions:          versions,
+			expecectedVersion: versions[0],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should return an error for latest-rc when there is no release candidate",
+			keyword:           "latest-rc",
+			versions:          versions[1:],
+			expecectedVersion: nil,
+			expectedError:     errors.New(`no version found for "latest-rc"`),
+		},
+		{
+			testTitle:         "should return an error when the keyword is not supported",
+			keyword:           "newest",
+			versions:          versions,
+			expecectedVersion: nil,
+			expectedError:     errors.New(`"newest" is not a supported keyword`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{RecentVersion: tc.recentVersion, PreviousVersion: tc.previousVersion}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			res, err := versioner.FindVersionBasedOnKeyword(tc.versions, tc.keyword)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(res, tc.expecectedVersion) {
+				t.Errorf("Wrong version received, got=%s", cmp.Diff(res, tc.expecectedVersion))
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+// ListFilter contains the filters that are applied to the versions from FilterVersions.
+type ListFilter struct {
+	// Installed keeps only the installed versions.
+	Installed bool
+
+	// Remote keeps only the versions that are not installed.
+	Remote bool
+
+	// ShowAll keeps the unstable versions (betas and release candidates) as well.
+	ShowAll bool
+
+	// SupportedOnly keeps only the versions that are supported by the Go team (see FindSupportedVersions).
+	SupportedOnly bool
+
+	// Constraint keeps only the versions that satisfy the constraint expression (e.g. `>=1.21`), if it's not empty.
+	Constraint string
+}
+
+// FilterVersions returns the versions that pass all the given filters, in the same order.
+// The unstable versions are kept if the constraint of the filter contains a pre-release, even if ShowAll is false.
+//
+// If the constraint can't be parsed, FilterVersions returns back an error.
+func FilterVersions(evs []*ExtendedVersion, filter ListFilter) ([]*ExtendedVersion, error) {
+	var constraint *Constraint
+	showAll := filter.ShowAll
+
+	if filter.Constraint != "" {
+		constraint = &Constraint{}
+		if err := ParseConstraint(filter.Constraint, constraint); err != nil {
+			return nil, err
+		}
+
+		showAll = showAll || constraint.HasPrerelease()
+	}
+
+	versions := []*ExtendedVersion{}
+	for _, ev := range evs {
+		if (filter.Installed && !ev.AlreadyInstalled) || (filter.Remote && ev.AlreadyInstalled) || (!showAll && !ev.IsStable) || (filter.SupportedOnly && ev.SupportStatus != Supported) {
+			continue
+		}
+
+		if constraint != nil {
+			semver, err := ev.getSemver()
+			if err != nil || !constraint.Check(*semver) {
+				continue
+			}
+		}
+
+		versions = append(versions, ev)
+	}
+
+	return versions, nil
+}
+
+// GetArchiveSize returns the size in bytes of the archive file of the version for the OS and the architecture type,
+// or 0 if there is no archive file for them.
+func (ev ExtendedVersion) GetArchiveSize(os string, arch string) uint64 {
+	for _, file := range ev.Files {
+		if file.Architecture == arch && file.OS == os && file.Kind == "archive" {
+			return file.Size
+		}
+	}
+
+	return 0
+}
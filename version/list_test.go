@@ -0,0 +1,118 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/api_client"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+func getListVersions() []*version.ExtendedVersion {
+	versions := getConstraintVersions()
+
+	for _, ev := range versions {
+		if ev.Version == "go1.22.1" || ev.Version == "go1.21.4" || ev.Version == "go1.23rc1" {
+			ev.AlreadyInstalled = true
+		}
+
+		ev.SupportStatus = version.Supported
+		if ev.Version == "go1.20.12" {
+			ev.SupportStatus = version.Unsupported
+		}
+	}
+
+	return versions
+}
+
+func TestFilterVersions(t *testing.T) {
+	testCases := []struct {
+		testTitle     string
+		filter        version.ListFilter
+		expectedNames []string
+		expectedError error
+	}{
+		{
+			testTitle:     "should return the stable versions by default",
+			filter:        version.ListFilter{},
+			expectedNames: []string{"go1.22.1", "go1.22.0", "go1.21.5", "go1.21.4", "go1.21.3", "go1.20.12"},
+		},
+		{
+			testTitle:     "should return the unstable versions as well when ShowAll is true",
+			filter:        version.ListFilter{ShowAll: true},
+			expectedNames: []string{"go1.23rc1", "go1.22.1", "go1.22.0", "go1.21.5", "go1.21.4", "go1.21.3", "go1.20.12"},
+		},
+		{
+			testTitle:     "should return only the installed versions",
+			filter:        version.ListFilter{Installed: true},
+			expectedNames: []string{"go1.22.1", "go1.21.4"},
+		},
+		{
+			testTitle:     "should return only the versions that are not installed",
+			filter:        version.ListFilter{Remote: true, ShowAll: true},
+			expectedNames: []string{"go1.22.0", "go1.21.5", "go1.21.3", "go1.20.12"},
+		},
+		{
+			testTitle:     "should return only the versions that satisfy the constraint",
+			filter:        version.ListFilter{Constraint: ">=1.21.4 <1.22"},
+			expectedNames: []string{"go1.21.5", "go1.21.4"},
+		},
+		{
+			testTitle:     "should return the unstable versions when the constraint contains a pre-release",
+			filter:        version.ListFilter{Constraint: ">=1.23rc1", Installed: true},
+			expectedNames: []string{"go1.23rc1"},
+		},
+		{
+			testTitle:     "should return only the supported versions",
+			filter:        version.ListFilter{SupportedOnly: true},
+			expectedNames: []string{"go1.22.1", "go1.22.0", "go1.21.5", "go1.21.4", "go1.21.3"},
+		},
+		{
+			testTitle:     "should return an error when the constraint is not valid",
+			filter:        version.ListFilter{Constraint: ">=foo"},
+			expectedError: errors.New(`invalid version "foo" in constraint ">=foo"`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			versions, err := version.FilterVersions(getListVersions(), tc.filter)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if tc.expectedError == nil && !cmp.Equal(getVersionNames(versions), tc.expectedNames) {
+				t.Errorf("Wrong versions received, got=%s", cmp.Diff(getVersionNames(versions), tc.expectedNames))
+			}
+		})
+	}
+}
+
+func TestGetArchiveSize(t *testing.T) {
+	ev := version.ExtendedVersion{
+		VersionInfo: api_client.VersionInfo{
+			Version: "go1.21.5",
+			Files: []api_client.FileInformation{
+				{OS: "linux", Architecture: "amd64", Kind: "installer", Size: 10},
+				{OS: "linux", Architecture: "amd64", Kind: "archive", Size: 20},
+				{OS: "darwin", Architecture: "arm64", Kind: "archive", Size: 30},
+			},
+		},
+	}
+
+	if size := ev.GetArchiveSize("linux", "amd64"); size != 20 {
+		t.Errorf("size should be 20, instead got %d", size)
+	}
+
+	if size := ev.GetArchiveSize("windows", "amd64"); size != 0 {
+		t.Errorf("size should be 0, instead got %d", size)
+	}
+}
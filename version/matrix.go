@@ -0,0 +1,118 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import "fmt"
+
+// SupportedQuery describes the versions that are supported by the Go team,
+// which are the newest stable versions of the two latest release lines.
+const SupportedQuery = "supported"
+
+// supportedReleaseLines is the count of the latest release lines that are supported by the Go team.
+const supportedReleaseLines = 2
+
+// findReleaseLineVersions returns the newest version of each release line, for the versions where
+// the match function returns true, sorted from the newest to the oldest release line.
+func findReleaseLineVersions(evs []*ExtendedVersion, match func(semver *Semver) bool) []*ExtendedVersion {
+	newest := map[string]*ExtendedVersion{}
+	newestSemvers := map[string]*Semver{}
+	lines := []string{}
+
+	for _, ev := range evs {
+		semver, err := ev.getSemver()
+		if err != nil || !match(semver) {
+			continue
+		}
+
+		line := getReleaseLine(semver)
+		key := line.GetVersion()
+
+		found, ok := newest[key]
+		if !ok {
+			lines = append(lines, key)
+		}
+
+		if found == nil || semver.Compare(*newestSemvers[key]) > 0 {
+			newest[key] = ev
+			newestSemvers[key] = semver
+		}
+	}
+
+	versions := make([]*ExtendedVersion, 0, len(lines))
+	for _, line := range lines {
+		versions = append(versions, newest[line])
+	}
+
+	SortVersions(versions)
+
+	return versions
+}
+
+// FindSupportedVersions returns the newest stable version of each of the release lines that are supported by the Go team
+// (the two latest release lines), sorted from the newest to the oldest.
+func FindSupportedVersions(evs []*ExtendedVersion) []*ExtendedVersion {
+	versions := findReleaseLineVersions(evs, func(semver *Semver) bool {
+		return !semver.IsPrerelease()
+	})
+
+	if len(versions) > supportedReleaseLines {
+		versions = versions[:supportedReleaseLines]
+	}
+
+	return versions
+}
+
+// ResolveMatrix returns the versions that are described from the given queries, in the order the queries are given,
+// without duplicates.
+//
+// Unlike Resolve, a query can describe multiple versions:
+//   - `supported` describes the newest version of each release line that is supported by the Go team (see FindSupportedVersions).
+//   - A constraint expression describes the newest version of each release line that satisfies it
+//     (e.g. `>=1.20` describes the newest 1.20, 1.21 and 1.22 versions).
+//
+// Any other query (e.g. a version, a keyword or an alias) is resolved with Resolve.
+//
+// If any of the queries can't be resolved, ResolveMatrix returns back an error.
+func (v Version) ResolveMatrix(evs []*ExtendedVersion, queries []string, includePrerelease bool) ([]*ExtendedVersion, error) {
+	versions := []*ExtendedVersion{}
+	added := map[*ExtendedVersion]bool{}
+
+	for _, query := range queries {
+		var found []*ExtendedVersion
+
+		switch {
+		case query == SupportedQuery:
+			found = FindSupportedVersions(evs)
+		case IsConstraint(query):
+			constraint := &Constraint{}
+			if err := ParseConstraint(query, constraint); err != nil {
+				return nil, err
+			}
+
+			allowPrerelease := includePrerelease || constraint.HasPrerelease()
+			found = findReleaseLineVersions(evs, func(semver *Semver) bool {
+				return (allowPrerelease || !semver.IsPrerelease()) && constraint.Check(*semver)
+			})
+		default:
+			ev, err := v.Resolve(evs, query, includePrerelease)
+			if err != nil {
+				return nil, err
+			}
+
+			found = []*ExtendedVersion{ev}
+		}
+
+		if len(found) == 0 {
+			return nil, fmt.Errorf("no version satisfies %q", query)
+		}
+
+		for _, ev := range found {
+			if !added[ev] {
+				added[ev] = true
+				versions = append(versions, ev)
+			}
+		}
+	}
+
+	return versions, nil
+}
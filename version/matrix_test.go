@@ -0,0 +1,106 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+// getVersionNames returns the names of the given versions, so they can be compared.
+func getVersionNames(evs []*version.ExtendedVersion) []string {
+	names := make([]string, 0, len(evs))
+	for _, ev := range evs {
+		names = append(names, ev.Version)
+	}
+
+	return names
+}
+
+func TestFindSupportedVersions(t *testing.T) {
+	versions := getConstraintVersions()
+
+	supported := version.FindSupportedVersions(versions)
+
+	expectedNames := []string{"go1.22.1", "go1.21.5"}
+	if !cmp.Equal(getVersionNames(supported), expectedNames) {
+		t.Errorf("Wrong versions received, got=%s", cmp.Diff(getVersionNames(supported), expectedNames))
+	}
+}
+
+func TestResolveMatrix(t *testing.T) {
+	testCases := []struct {
+		testTitle         string
+		queries           []string
+		includePrerelease bool
+		expectedNames     []string
+		expectedError     error
+	}{
+		{
+			testTitle:     "should resolve each version",
+			queries:       []string{"1.20", "1.21", "1.22"},
+			expectedNames: []string{"go1.20.12", "go1.21.5", "go1.22.1"},
+		},
+		{
+			testTitle:     "should resolve the newest version of each release line for a constraint",
+			queries:       []string{">=1.21"},
+			expectedNames: []string{"go1.22.1", "go1.21.5"},
+		},
+		{
+			testTitle:         "should include the pre-releases for a constraint when includePrerelease is true",
+			queries:           []string{">=1.22"},
+			includePrerelease: true,
+			expectedNames:     []string{"go1.23rc1", "go1.22.1"},
+		},
+		{
+			testTitle:     "should resolve the supported versions",
+			queries:       []string{"supported"},
+			expectedNames: []string{"go1.22.1", "go1.21.5"},
+		},
+		{
+			testTitle:     "should remove the duplicate versions",
+			queries:       []string{"1.21.4", "supported", "1.22"},
+			expectedNames: []string{"go1.21.4", "go1.22.1", "go1.21.5"},
+		},
+		{
+			testTitle:     "should return an error when no version satisfies the constraint",
+			queries:       []string{">=1.24"},
+			expectedError: errors.New(`no version satisfies ">=1.24"`),
+		},
+		{
+			testTitle:     "should return an error when a version can't be resolved",
+			queries:       []string{"1.21", "1.19"},
+			expectedError: errors.New("1.19 is not a valid version, the closest versions are: 1.20.12"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			versions, err := versioner.ResolveMatrix(getConstraintVersions(), tc.queries, tc.includePrerelease)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if tc.expectedError == nil && !cmp.Equal(getVersionNames(versions), tc.expectedNames) {
+				t.Errorf("Wrong versions received, got=%s", cmp.Diff(getVersionNames(versions), tc.expectedNames))
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import "fmt"
+
+const (
+	// ModDirectiveGo is the `go` directive of the go.mod file.
+	ModDirectiveGo = "go"
+
+	// ModDirectiveToolchain is the `toolchain` directive of the go.mod file.
+	ModDirectiveToolchain = "toolchain"
+)
+
+// getHighestVersion parses the given versions and returns the highest of them,
+// or nil if there are no versions.
+//
+// If any of the versions is not valid, getHighestVersion returns back an error that contains the directive name.
+func getHighestVersion(versions []string, directive string) (*Semver, error) {
+	var highest *Semver
+
+	for _, version := range versions {
+		semver := &Semver{}
+		if err := ParseSemver(version, semver); err != nil {
+			return nil, fmt.Errorf("invalid %s directive %q: %w", directive, version, err)
+		}
+
+		if highest == nil || semver.Compare(*highest) > 0 {
+			highest = semver
+		}
+	}
+
+	return highest, nil
+}
+
+// GetModVersionQuery returns the version query that should be resolved for the versions of a go.mod
+// or a go.work file, along with the directive it was based on.
+//
+// When there are multiple versions for a directive (e.g. from the modules of a workspace),
+// the highest of them is used, since it is the requirement of the whole workspace.
+//
+// The `toolchain` directive names the exact toolchain, so it is preferred over the `go` directive,
+// unless it is older than the `go` directive (which is how the go command ignores it as well).
+//
+// Since Go 1.21, the `go` directive is the minimum required version, so `go 1.21` means `1.21.0`.
+// Before Go 1.21, the `go` directive did not name a release, so `go 1.20` means the latest 1.20 patch version.
+//
+// If latestPatch is true, the latest patch version of the selected directive is used instead,
+// which is how the go.mod versions were resolved before the Go 1.21 semantics were supported.
+//
+// If any of the versions is not valid, or there are no versions, GetModVersionQuery returns back an error.
+func GetModVersionQuery(goVersions []string, toolchains []string, latestPatch bool) (string, string, error) {
+	goSemver, err := getHighestVersion(goVersions, ModDirectiveGo)
+	if err != nil {
+		return "", "", err
+	}
+
+	toolchainSemver, err := getHighestVersion(toolchains, ModDirectiveToolchain)
+	if err != nil {
+		return "", "", err
+	}
+
+	if goSemver == nil && toolchainSemver == nil {
+		return "", "", fmt.Errorf("there is no go or toolchain directive")
+	}
+
+	directive := ModDirectiveGo
+	semver := goSemver
+
+	if toolchainSemver != nil && (goSemver == nil || toolchainSemver.Compare(*goSemver) >= 0) {
+		directive = ModDirectiveToolchain
+		semver = toolchainSemver
+	}
+
+	if latestPatch {
+		line := getReleaseLine(semver)
+		return line.GetVersion(), directive, nil
+	}
+
+	if semver.Minor != nil && semver.Patch == nil && !semver.IsPrerelease() && semver.getPatch() == nil {
+		var zero uint64 = 0
+		exact := Semver{Major: semver.Major, Minor: semver.Minor, Patch: &zero}
+		return exact.GetVersion(), directive, nil
+	}
+
+	return semver.GetVersion(), directive, nil
+}
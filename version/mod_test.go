@@ -0,0 +1,132 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/version"
+)
+
+func TestGetModVersionQuery(t *testing.T) {
+	testCases := []struct {
+		testTitle         string
+		goVersions        []string
+		toolchains        []string
+		latestPatch       bool
+		expectedQuery     string
+		expectedDirective string
+		expectedError     error
+	}{
+		{
+			testTitle:         "should return the exact version for the go directive since Go 1.21",
+			goVersions:        []string{"1.21"},
+			expectedQuery:     "1.21.0",
+			expectedDirective: "go",
+		},
+		{
+			testTitle:         "should return the latest patch version for the go directive before Go 1.21",
+			goVersions:        []string{"1.20"},
+			expectedQuery:     "1.20",
+			expectedDirective: "go",
+		},
+		{
+			testTitle:         "should return the go directive when it contains the patch version",
+			goVersions:        []string{"1.21.3"},
+			expectedQuery:     "1.21.3",
+			expectedDirective: "go",
+		},
+		{
+			testTitle:         "should return the go directive when it is a pre-release",
+			goVersions:        []string{"1.22rc1"},
+			expectedQuery:     "1.22rc1",
+			expectedDirective: "go",
+		},
+		{
+			testTitle:         "should prefer the toolchain directive",
+			goVersions:        []string{"1.21"},
+			toolchains:        []string{"1.21.5"},
+			expectedQuery:     "1.21.5",
+			expectedDirective: "toolchain",
+		},
+		{
+			testTitle:         "should return the toolchain directive when the go directive is missing",
+			toolchains:        []string{"1.22.1"},
+			expectedQuery:     "1.22.1",
+			expectedDirective: "toolchain",
+		},
+		{
+			testTitle:         "should ignore the toolchain directive when it is older than the go directive",
+			goVersions:        []string{"1.22.0"},
+			toolchains:        []string{"1.21.5"},
+			expectedQuery:     "1.22.0",
+			expectedDirective: "go",
+		},
+		{
+			testTitle:         "should return the latest patch version of the go directive when latestPatch is true",
+			goVersions:        []string{"1.21"},
+			latestPatch:       true,
+			expectedQuery:     "1.21",
+			expectedDirective: "go",
+		},
+		{
+			testTitle:         "should return the latest patch version of the toolchain directive when latestPatch is true",
+			goVersions:        []string{"1.21"},
+			toolchains:        []string{"1.21.5"},
+			latestPatch:       true,
+			expectedQuery:     "1.21",
+			expectedDirective: "toolchain",
+		},
+		{
+			testTitle:         "should return the highest go directive of a workspace",
+			goVersions:        []string{"1.21", "1.22.1", "1.20"},
+			expectedQuery:     "1.22.1",
+			expectedDirective: "go",
+		},
+		{
+			testTitle:         "should return the highest toolchain directive of a workspace",
+			goVersions:        []string{"1.21", "1.21.2"},
+			toolchains:        []string{"1.21.5", "1.22.0"},
+			expectedQuery:     "1.22.0",
+			expectedDirective: "toolchain",
+		},
+		{
+			testTitle:     "should return an error when there are no versions",
+			expectedError: errors.New("there is no go or toolchain directive"),
+		},
+		{
+			testTitle:     "should return an error when the go directive is not valid",
+			goVersions:    []string{"1.21.x"},
+			expectedError: errors.New(`invalid go directive "1.21.x": invalid Go version`),
+		},
+		{
+			testTitle:     "should return an error when the toolchain directive is not valid",
+			goVersions:    []string{"1.21"},
+			toolchains:    []string{"default"},
+			expectedError: errors.New(`invalid toolchain directive "default": invalid Go version`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			query, directive, err := version.GetModVersionQuery(tc.goVersions, tc.toolchains, tc.latestPatch)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if query != tc.expectedQuery {
+				t.Errorf("query should be %q, instead got %q", tc.expectedQuery, query)
+			}
+
+			if directive != tc.expectedDirective {
+				t.Errorf("directive should be %q, instead got %q", tc.expectedDirective, directive)
+			}
+		})
+	}
+}
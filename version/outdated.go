@@ -0,0 +1,102 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import (
+	"os"
+
+	"github.com/VassilisPallas/gvs/api_client"
+)
+
+// OutdatedVersion contains the state of an installed version, compared to the available versions.
+type OutdatedVersion struct {
+	// Version is the installed version.
+	Version *ExtendedVersion
+
+	// Latest is the newest stable patch of the release line of the installed version,
+	// or nil if the installed version is already the newest one.
+	Latest *ExtendedVersion
+
+	// Supported is true if the release line of the installed version is supported by the Go team (see FindSupportedVersions).
+	Supported bool
+}
+
+// IsOutdated returns if there is a newer stable patch of the installed version.
+func (o OutdatedVersion) IsOutdated() bool {
+	return o.Latest != nil
+}
+
+// FindOutdatedVersions returns the state of every installed version of the given versions, in the order they are given,
+// with the newest stable patch of its release line (if it's newer) and whether its release line is still supported by the Go team.
+func FindOutdatedVersions(evs []*ExtendedVersion) []*OutdatedVersion {
+	oldestSupportedLine := findOldestSupportedLine(evs)
+
+	outdatedVersions := []*OutdatedVersion{}
+
+	for _, ev := range evs {
+		if !ev.AlreadyInstalled {
+			continue
+		}
+
+		semver, err := ev.getSemver()
+		if err != nil {
+			continue
+		}
+
+		line := getReleaseLine(semver)
+		outdated := &OutdatedVersion{
+			Version:   ev,
+			Supported: getSupportStatus(semver, oldestSupportedLine) == Supported,
+		}
+
+		latest := findNewestVersion(evs, func(s *Semver) bool {
+			return !s.IsPrerelease() && line.Includes(*s)
+		})
+		if latest != nil {
+			latestSemver, _ := latest.getSemver()
+			if latestSemver.Compare(*semver) > 0 {
+				outdated.Latest = latest
+			}
+		}
+
+		outdatedVersions = append(outdatedVersions, outdated)
+	}
+
+	return outdatedVersions
+}
+
+// RefreshVersions fetches the available versions from the API, the same as GetVersions does when the fetch is forced,
+// and also returns the versions that are added since the last time the versions were cached, sorted from the newest to the oldest.
+// The cached versions are read even if the cache has expired, since the added versions are the ones since the last fetch.
+// If the versions were not cached before, there are no added versions.
+//
+// If the versions can't be fetched or cached, RefreshVersions returns back an error.
+func (v Version) RefreshVersions() ([]*ExtendedVersion, []*ExtendedVersion, error) {
+	cachedVersions := map[string]bool{}
+
+	// the cached versions are only used to find the added versions, so they are not required.
+	var responseVersions []api_client.VersionInfo
+	if err := v.fileHelpers.GetCachedResponse(&responseVersions); err != nil && !os.IsNotExist(err) {
+		v.log.Error("the cached versions can't be read: %s\n", err.Error())
+	}
+
+	for _, rv := range responseVersions {
+		cachedVersions[rv.Version] = true
+	}
+
+	versions, err := v.GetVersions(true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addedVersions := []*ExtendedVersion{}
+	if len(cachedVersions) > 0 {
+		for _, ev := range versions {
+			if !cachedVersions[ev.Version] {
+				addedVersions = append(addedVersions, ev)
+			}
+		}
+	}
+
+	return versions, addedVersions, nil
+}
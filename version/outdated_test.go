@@ -0,0 +1,132 @@
+package version_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/api_client"
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindOutdatedVersions(t *testing.T) {
+	versions := getConstraintVersions()
+	versions = append(versions, &version.ExtendedVersion{
+		VersionInfo: api_client.VersionInfo{Version: "go1.24rc1", Files: []api_client.FileInformation{}},
+	})
+
+	installed := map[string]bool{"go1.24rc1": true, "go1.22.1": true, "go1.21.3": true, "go1.20.12": true}
+	for _, ev := range versions {
+		ev.AlreadyInstalled = installed[ev.Version]
+	}
+
+	outdatedVersions := version.FindOutdatedVersions(versions)
+
+	type result struct {
+		Version   string
+		Latest    string
+		Supported bool
+	}
+
+	results := []result{}
+	for _, outdated := range outdatedVersions {
+		res := result{Version: outdated.Version.Version, Supported: outdated.Supported}
+		if outdated.IsOutdated() {
+			res.Latest = outdated.Latest.Version
+		}
+
+		results = append(results, res)
+	}
+
+	expected := []result{
+		{Version: "go1.22.1", Supported: true},
+		{Version: "go1.21.3", Latest: "go1.21.5", Supported: true},
+		{Version: "go1.20.12", Supported: false},
+		{Version: "go1.24rc1", Supported: true},
+	}
+
+	if !cmp.Equal(results, expected) {
+		t.Errorf("Wrong versions received, got=%s", cmp.Diff(results, expected))
+	}
+}
+
+func TestRefreshVersions(t *testing.T) {
+	fetchedVersions := []api_client.VersionInfo{
+		{Version: "go1.22.0", IsStable: true},
+		{Version: "go1.21.0", IsStable: true},
+		{Version: "go1.20.0", IsStable: true},
+		{Version: "go1.19.0", IsStable: true},
+	}
+
+	testCases := []struct {
+		testTitle             string
+		fileHelpers           *testutils.FakeFilesHelper
+		fetchVersionsError    error
+		expectedAddedVersions []string
+		expectedError         error
+	}{
+		{
+			testTitle:             "should return the versions that are added since the last cache",
+			fileHelpers:           &testutils.FakeFilesHelper{CachedVersion: true},
+			expectedAddedVersions: []string{"go1.22.0"},
+		},
+		{
+			testTitle:             "should return the versions that are added since the last cache when the cache has expired",
+			fileHelpers:           &testutils.FakeFilesHelper{CachedVersion: false},
+			expectedAddedVersions: []string{"go1.22.0"},
+		},
+		{
+			testTitle:             "should not return any added versions when the versions are not cached",
+			fileHelpers:           &testutils.FakeFilesHelper{CacheResponseError: &fs.PathError{Op: "open", Path: "/home/user/.gvs/goVersions.json", Err: fs.ErrNotExist}},
+			expectedAddedVersions: []string{},
+		},
+		{
+			testTitle:             "should not return any added versions when the cache can't be read",
+			fileHelpers:           &testutils.FakeFilesHelper{CachedVersion: true, CacheResponseError: errors.New("an error occurred while reading the cache")},
+			expectedAddedVersions: []string{},
+		},
+		{
+			testTitle:          "should return an error when the versions can't be fetched",
+			fileHelpers:        &testutils.FakeFilesHelper{CachedVersion: true},
+			fetchVersionsError: errors.New("an error occurred while fetching the versions"),
+			expectedError:      errors.New("an error occurred while fetching the versions"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			clientAPI := testutils.FakeGoClientAPI{FetchedVersions: fetchedVersions, FetchVersionsError: tc.fetchVersionsError}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(tc.fileHelpers, clientAPI, installer, log)
+
+			versions, addedVersions, err := versioner.RefreshVersions()
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if tc.expectedError != nil {
+				return
+			}
+
+			if len(versions) != len(fetchedVersions) {
+				t.Errorf("versions should be %d, instead got %d", len(fetchedVersions), len(versions))
+			}
+
+			if !cmp.Equal(getVersionNames(addedVersions), tc.expectedAddedVersions) {
+				t.Errorf("Wrong added versions received, got=%s", cmp.Diff(getVersionNames(addedVersions), tc.expectedAddedVersions))
+			}
+		})
+	}
+}
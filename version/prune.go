@@ -0,0 +1,178 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import "encoding/json"
+
+// PrunePolicy describes which installed versions are kept from `gvs prune`. The current version is always kept,
+// and every other installed version is deleted, unless any of the rules of the policy keeps it.
+type PrunePolicy struct {
+	// KeepNewest keeps the given count of the newest installed versions.
+	KeepNewest int `json:"keep_newest"`
+
+	// KeepLatestPatches keeps the newest installed version of each release line (e.g. the newest 1.21 and the newest 1.20).
+	KeepLatestPatches bool `json:"keep_latest_patches"`
+
+	// KeepUsedDays keeps the versions that were used (or installed) in the last given days.
+	KeepUsedDays int `json:"keep_used_days"`
+
+	// KeepAliased keeps the versions that any of the aliases point to.
+	KeepAliased bool `json:"keep_aliased"`
+
+	// KeepPinned keeps the versions of the current directory and the global default version (see GetVersionSources).
+	KeepPinned bool `json:"keep_pinned"`
+
+	// Auto prunes the versions after every install or download of a new version.
+	Auto bool `json:"auto"`
+}
+
+// HasRules returns if any of the rules that keep versions is set.
+func (p PrunePolicy) HasRules() bool {
+	return p.KeepNewest > 0 || p.KeepLatestPatches || p.KeepUsedDays > 0 || p.KeepAliased || p.KeepPinned
+}
+
+// DefaultPrunePolicy is the policy that is used when no policy is stored.
+var DefaultPrunePolicy = PrunePolicy{
+	KeepLatestPatches: true,
+	KeepAliased:       true,
+	KeepPinned:        true,
+}
+
+// withDefaultRules returns the given policy, or the rules of DefaultPrunePolicy together with the Auto field of the given policy
+// if it has no rules, since a policy without rules keeps only the current version.
+func (p PrunePolicy) withDefaultRules() PrunePolicy {
+	if p.HasRules() {
+		return p
+	}
+
+	policy := DefaultPrunePolicy
+	policy.Auto = p.Auto
+
+	return policy
+}
+
+// GetPrunePolicy returns the stored retention policy of `gvs prune`, or DefaultPrunePolicy if there is none.
+// If the stored policy has no rules, the rules of DefaultPrunePolicy are used instead.
+//
+// If the policy can't be read, GetPrunePolicy returns back an error.
+func (v Version) GetPrunePolicy() (PrunePolicy, error) {
+	content, err := v.fileHelpers.GetPrunePolicy()
+	if err != nil {
+		return PrunePolicy{}, err
+	}
+
+	if content == nil {
+		return DefaultPrunePolicy, nil
+	}
+
+	policy := PrunePolicy{}
+	if err := json.Unmarshal(content, &policy); err != nil {
+		return PrunePolicy{}, err
+	}
+
+	return policy.withDefaultRules(), nil
+}
+
+// SetPrunePolicy stores the given retention policy of `gvs prune`, and returns back the stored policy.
+// If the policy has no rules, the rules of DefaultPrunePolicy are stored instead, so the policy never deletes every version.
+//
+// If the policy can't be stored, SetPrunePolicy returns back an error.
+func (v Version) SetPrunePolicy(policy PrunePolicy) (PrunePolicy, error) {
+	policy = policy.withDefaultRules()
+
+	content, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return PrunePolicy{}, err
+	}
+
+	if err := v.fileHelpers.StorePrunePolicy(content); err != nil {
+		return PrunePolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// UpdateVersionUsage stores the current time as the time the given installed version was last used,
+// which is used from the KeepUsedDays rule of the policy.
+//
+// If the time can't be stored, UpdateVersionUsage returns back an error.
+func (v Version) UpdateVersionUsage(ev *ExtendedVersion) error {
+	return v.fileHelpers.UpdateVersionUsage(ev.Version)
+}
+
+// GetVersionSize returns the disk size in bytes of the given installed version.
+//
+// If the directory of the version can't be read, GetVersionSize returns back an error.
+func (v Version) GetVersionSize(ev *ExtendedVersion) (int64, error) {
+	return v.fileHelpers.GetVersionSize(ev.Version)
+}
+
+// findPinnedVersions returns the installed versions that the current directory and the global default version resolve to.
+// The sources that can't be read or resolved are ignored.
+func (v Version) findPinnedVersions(evs []*ExtendedVersion) map[*ExtendedVersion]bool {
+	pinned := map[*ExtendedVersion]bool{}
+
+	sources, err := v.GetVersionSources(false)
+	if err != nil {
+		v.log.Info("the pinned versions can't be found: %s\n", err.Error())
+	}
+
+	for _, source := range sources {
+		if ev, err := v.Resolve(evs, source.Query, true); err == nil {
+			pinned[ev] = true
+		}
+	}
+
+	return pinned
+}
+
+// FindPrunableVersions returns the installed versions that are not kept from the given policy, which can be deleted.
+// The given versions must be the installed versions, sorted from the newest to the oldest (see GetInstalledVersions).
+// The current version is always kept.
+//
+// If the aliases can't be read, FindPrunableVersions returns back an error.
+func (v Version) FindPrunableVersions(evs []*ExtendedVersion, policy PrunePolicy) ([]*ExtendedVersion, error) {
+	keep := map[*ExtendedVersion]bool{}
+
+	for i, ev := range evs {
+		if ev.UsedVersion || i < policy.KeepNewest {
+			keep[ev] = true
+		}
+
+		if policy.KeepUsedDays > 0 && v.fileHelpers.IsVersionUsedWithin(ev.Version, policy.KeepUsedDays) {
+			keep[ev] = true
+		}
+
+		if policy.KeepAliased {
+			aliases, err := v.GetAliasesForVersion(evs, ev)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(aliases) > 0 {
+				keep[ev] = true
+			}
+		}
+	}
+
+	if policy.KeepLatestPatches {
+		for _, ev := range findReleaseLineVersions(evs, func(semver *Semver) bool { return true }) {
+			keep[ev] = true
+		}
+	}
+
+	if policy.KeepPinned {
+		for ev := range v.findPinnedVersions(evs) {
+			keep[ev] = true
+		}
+	}
+
+	prunable := []*ExtendedVersion{}
+	for _, ev := range evs {
+		if !keep[ev] {
+			prunable = append(prunable, ev)
+		}
+	}
+
+	return prunable, nil
+}
@@ -0,0 +1,292 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/api_client"
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+func getInstalledPruneVersions() []*version.ExtendedVersion {
+	names := []string{"go1.22.1", "go1.22.0", "go1.21.5", "go1.21.4", "go1.20.12", "go1.20.1"}
+
+	versions := make([]*version.ExtendedVersion, 0, len(names))
+	for _, name := range names {
+		versions = append(versions, &version.ExtendedVersion{
+			AlreadyInstalled: true,
+			UsedVersion:      name == "go1.21.5",
+			VersionInfo:      api_client.VersionInfo{Version: name, IsStable: true},
+		})
+	}
+
+	return versions
+}
+
+func TestFindPrunableVersions(t *testing.T) {
+	modFileError := errors.New("go.mod or go.work file not found in the current directory or any of its parent directories")
+
+	testCases := []struct {
+		testTitle     string
+		policy        version.PrunePolicy
+		fileHelpers   *testutils.FakeFilesHelper
+		expectedNames []string
+		expectedError error
+	}{
+		{
+			testTitle:     "should keep only the current version without rules",
+			policy:        version.PrunePolicy{},
+			fileHelpers:   &testutils.FakeFilesHelper{FindModFileError: modFileError},
+			expectedNames: []string{"go1.22.1", "go1.22.0", "go1.21.4", "go1.20.12", "go1.20.1"},
+		},
+		{
+			testTitle:     "should keep the newest versions",
+			policy:        version.PrunePolicy{KeepNewest: 2},
+			fileHelpers:   &testutils.FakeFilesHelper{FindModFileError: modFileError},
+			expectedNames: []string{"go1.21.4", "go1.20.12", "go1.20.1"},
+		},
+		{
+			testTitle:     "should keep the latest patch of each release line",
+			policy:        version.PrunePolicy{KeepLatestPatches: true},
+			fileHelpers:   &testutils.FakeFilesHelper{FindModFileError: modFileError},
+			expectedNames: []string{"go1.22.0", "go1.21.4", "go1.20.1"},
+		},
+		{
+			testTitle:     "should keep the recently used versions",
+			policy:        version.PrunePolicy{KeepUsedDays: 30},
+			fileHelpers:   &testutils.FakeFilesHelper{FindModFileError: modFileError, RecentlyUsedVersions: []string{"go1.20.1", "go1.22.0"}},
+			expectedNames: []string{"go1.22.1", "go1.21.4", "go1.20.12"},
+		},
+		{
+			testTitle:     "should keep the aliased versions",
+			policy:        version.PrunePolicy{KeepAliased: true},
+			fileHelpers:   &testutils.FakeFilesHelper{FindModFileError: modFileError, Aliases: map[string]string{"old": "1.20.1", "work": "1.22"}},
+			expectedNames: []string{"go1.22.0", "go1.21.4", "go1.20.12"},
+		},
+		{
+			testTitle: "should keep the pinned versions",
+			policy:    version.PrunePolicy{KeepPinned: true},
+			fileHelpers: &testutils.FakeFilesHelper{
+				VersionFile:        "/project/.go-version",
+				VersionFileVersion: "1.21.4",
+				FindModFileError:   modFileError,
+				GlobalVersion:      "1.20",
+			},
+			expectedNames: []string{"go1.22.1", "go1.22.0", "go1.20.1"},
+		},
+		{
+			testTitle:     "should return an error when the aliases can't be read",
+			policy:        version.PrunePolicy{KeepAliased: true},
+			fileHelpers:   &testutils.FakeFilesHelper{GetAliasesError: errors.New("an error occurred while reading the aliases")},
+			expectedError: errors.New("an error occurred while reading the aliases"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			t.Setenv(version.VersionEnvName, "")
+
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(tc.fileHelpers, clientAPI, installer, log)
+
+			versions, err := versioner.FindPrunableVersions(getInstalledPruneVersions(), tc.policy)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if tc.expectedError == nil && !cmp.Equal(getVersionNames(versions), tc.expectedNames) {
+				t.Errorf("Wrong versions received, got=%s", cmp.Diff(getVersionNames(versions), tc.expectedNames))
+			}
+		})
+	}
+}
+
+func TestGetPrunePolicy(t *testing.T) {
+	testCases := []struct {
+		testTitle      string
+		fileHelpers    *testutils.FakeFilesHelper
+		expectedPolicy version.PrunePolicy
+		expectedError  error
+	}{
+		{
+			testTitle:      "should return the default policy when there is no stored policy",
+			fileHelpers:    &testutils.FakeFilesHelper{},
+			expectedPolicy: version.DefaultPrunePolicy,
+		},
+		{
+			testTitle:      "should return the stored policy",
+			fileHelpers:    &testutils.FakeFilesHelper{PrunePolicy: []byte(`{"keep_newest": 3, "auto": true}`)},
+			expectedPolicy: version.PrunePolicy{KeepNewest: 3, Auto: true},
+		},
+		{
+			testTitle:      "should return the default rules when the stored policy has no rules",
+			fileHelpers:    &testutils.FakeFilesHelper{PrunePolicy: []byte(`{"auto": true}`)},
+			expectedPolicy: version.PrunePolicy{KeepLatestPatches: true, KeepAliased: true, KeepPinned: true, Auto: true},
+		},
+		{
+			testTitle:     "should return an error when the policy can't be read",
+			fileHelpers:   &testutils.FakeFilesHelper{GetPrunePolicyError: errors.New("an error occurred while reading the policy")},
+			expectedError: errors.New("an error occurred while reading the policy"),
+		},
+		{
+			testTitle:     "should return an error when the policy is not valid",
+			fileHelpers:   &testutils.FakeFilesHelper{PrunePolicy: []byte(`not json`)},
+			expectedError: errors.New("invalid character 'o' in literal null (expecting 'u')"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(tc.fileHelpers, clientAPI, installer, log)
+
+			policy, err := versioner.GetPrunePolicy()
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if policy != tc.expectedPolicy {
+				t.Errorf("policy should be %+v, instead got %+v", tc.expectedPolicy, policy)
+			}
+		})
+	}
+}
+
+func TestSetPrunePolicy(t *testing.T) {
+	fileHelpers := &testutils.FakeFilesHelper{}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	policy := version.PrunePolicy{KeepUsedDays: 30, KeepAliased: true, Auto: true}
+	if _, err := versioner.SetPrunePolicy(policy); err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	storedPolicy, err := versioner.GetPrunePolicy()
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	if storedPolicy != policy {
+		t.Errorf("policy should be %+v, instead got %+v", policy, storedPolicy)
+	}
+}
+
+func TestSetPrunePolicyWithoutRules(t *testing.T) {
+	t.Setenv(version.VersionEnvName, "")
+
+	fileHelpers := &testutils.FakeFilesHelper{FindModFileError: errors.New("go.mod or go.work file not found in the current directory or any of its parent directories")}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	storedPolicy, err := versioner.SetPrunePolicy(version.PrunePolicy{Auto: true})
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	expectedPolicy := version.DefaultPrunePolicy
+	expectedPolicy.Auto = true
+
+	if storedPolicy != expectedPolicy {
+		t.Errorf("stored policy should be %+v, instead got %+v", expectedPolicy, storedPolicy)
+	}
+
+	policy, err := versioner.GetPrunePolicy()
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	if policy != expectedPolicy {
+		t.Errorf("policy should be %+v, instead got %+v", expectedPolicy, policy)
+	}
+
+	versions, err := versioner.FindPrunableVersions(getInstalledPruneVersions(), policy)
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	expectedNames := []string{"go1.22.0", "go1.21.4", "go1.20.1"}
+	if !cmp.Equal(getVersionNames(versions), expectedNames) {
+		t.Errorf("Wrong versions received, got=%s", cmp.Diff(getVersionNames(versions), expectedNames))
+	}
+}
+
+func TestUpdateVersionUsage(t *testing.T) {
+	testCases := []struct {
+		testTitle     string
+		usageError    error
+		expectedUsed  []string
+		expectedError error
+	}{
+		{
+			testTitle:    "should store the usage of the version",
+			expectedUsed: []string{"go1.21.5"},
+		},
+		{
+			testTitle:     "should return an error when the usage can't be stored",
+			usageError:    errors.New("an error occurred while storing the usage"),
+			expectedError: errors.New("an error occurred while storing the usage"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{UpdateVersionUsageError: tc.usageError}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			err := versioner.UpdateVersionUsage(&version.ExtendedVersion{VersionInfo: api_client.VersionInfo{Version: "go1.21.5"}})
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(fileHelpers.UsedVersions, tc.expectedUsed) {
+				t.Errorf("Wrong used versions, got=%s", cmp.Diff(fileHelpers.UsedVersions, tc.expectedUsed))
+			}
+		})
+	}
+}
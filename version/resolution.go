@@ -0,0 +1,86 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+// ResolutionStep describes how the version of a source is resolved.
+type ResolutionStep struct {
+	// Source is where the version was found.
+	Source VersionSource
+
+	// Alias is the version the alias of the source points to, or empty if the version of the source is not an alias.
+	Alias string
+
+	// Semver is the parsed version of the source, or nil if the version of the source is a keyword or a constraint expression.
+	Semver *Semver
+
+	// Version is the version the source is resolved to, or nil if it can't be resolved.
+	Version *ExtendedVersion
+
+	// Error is the reason the source can't be resolved, or nil if it's resolved.
+	Error error
+
+	// Selected indicates if the source is the one that decides the version.
+	Selected bool
+}
+
+// ExplainVersion returns the resolution chain of the version that applies to the current directory.
+//
+// The chain contains a step for every source that has a version, in the order FindProjectVersion looks them up,
+// where the first one is selected and the rest are overridden. If query is not empty, it's the version that is passed
+// on the command line, which is selected over any other source.
+//
+// The sources that can't be read (e.g. a go.mod file that can't be parsed) and the versions that can't be resolved
+// don't stop the chain, and the reason is stored on the step instead.
+//
+// If no version applies to the current directory, or the aliases can't be read, ExplainVersion returns back an error.
+func (v Version) ExplainVersion(evs []*ExtendedVersion, query string, latestPatch bool, includePrerelease bool) ([]*ResolutionStep, error) {
+	sources := []*VersionSource{}
+
+	if query != "" {
+		sources = append(sources, &VersionSource{Kind: SourceFlag, Query: query})
+	}
+
+	projectSources, err := v.findVersionSources(latestPatch, false, true)
+	if err != nil {
+		return nil, err
+	}
+
+	sources = append(sources, projectSources...)
+	if len(sources) == 0 {
+		return nil, noProjectVersionError()
+	}
+
+	aliases, err := v.fileHelpers.GetAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]*ResolutionStep, 0, len(sources))
+	for i, source := range sources {
+		step := &ResolutionStep{Source: *source, Selected: i == 0}
+
+		if source.Error != nil {
+			step.Error = source.Error
+			steps = append(steps, step)
+			continue
+		}
+
+		resolvedQuery := source.Query
+		if aliasVersion, ok := aliases[resolvedQuery]; ok {
+			step.Alias = aliasVersion
+			resolvedQuery = aliasVersion
+		}
+
+		if !IsKeyword(resolvedQuery) && !IsConstraint(resolvedQuery) {
+			semver := &Semver{}
+			if err := ParseSemver(resolvedQuery, semver); err == nil {
+				step.Semver = semver
+			}
+		}
+
+		step.Version, step.Error = v.resolveQuery(evs, resolvedQuery, includePrerelease)
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
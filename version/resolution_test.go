@@ -0,0 +1,163 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+)
+
+func TestExplainVersion(t *testing.T) {
+	versions := getConstraintVersions()
+
+	type expectedStep struct {
+		kind     string
+		alias    string
+		semver   string
+		version  *version.ExtendedVersion
+		error    string
+		selected bool
+	}
+
+	testCases := []struct {
+		testTitle     string
+		env           string
+		query         string
+		fileHelpers   *testutils.FakeFilesHelper
+		expectedSteps []expectedStep
+		expectedError error
+	}{
+		{
+			testTitle: "should return every source in the order they are looked up",
+			env:       "latest",
+			query:     "1.21",
+			fileHelpers: &testutils.FakeFilesHelper{
+				VersionFile:        "/project/.go-version",
+				VersionFileVersion: "~1.20",
+				ModFile:            "/project/go.mod",
+				ModGoVersions:      []string{"1.21"},
+				GlobalVersion:      "1.19",
+			},
+			expectedSteps: []expectedStep{
+				{kind: version.SourceFlag, semver: "1.21", version: versions[3], selected: true},
+				{kind: version.SourceEnv, version: versions[1]},
+				{kind: version.SourceVersionFile, version: versions[6]},
+				{kind: version.SourceModFile, semver: "1.21.0", error: "1.21.0 is not a valid version, the closest versions are: 1.20.12, 1.21.3"},
+				{kind: version.SourceGlobal, semver: "1.19", error: "1.19 is not a valid version, the closest versions are: 1.20.12"},
+			},
+		},
+		{
+			testTitle: "should select the first source when no version is passed",
+			fileHelpers: &testutils.FakeFilesHelper{
+				FindModFileError: errors.New("go.mod or go.work file not found in the current directory or any of its parent directories"),
+				GlobalVersion:    "work",
+				Aliases:          map[string]string{"work": "1.21.4"},
+			},
+			expectedSteps: []expectedStep{
+				{kind: version.SourceGlobal, alias: "1.21.4", semver: "1.21.4", version: versions[4], selected: true},
+			},
+		},
+		{
+			testTitle: "should keep the sources that can't be read with their error",
+			query:     "1.21",
+			fileHelpers: &testutils.FakeFilesHelper{
+				ModFile:                 "/project/go.mod",
+				ReadVersionFromModError: errors.New("/project/go.mod:3: unknown directive: gox"),
+				GetGlobalVersionError:   errors.New("permission denied"),
+			},
+			expectedSteps: []expectedStep{
+				{kind: version.SourceFlag, semver: "1.21", version: versions[3], selected: true},
+				{kind: version.SourceModFile, error: "/project/go.mod:3: unknown directive: gox"},
+				{kind: version.SourceGlobal, error: "permission denied"},
+			},
+		},
+		{
+			testTitle: "should select a source that can't be read when it's the first one",
+			fileHelpers: &testutils.FakeFilesHelper{
+				FindVersionFileError: errors.New("permission denied"),
+				FindModFileError:     errors.New("go.mod or go.work file not found in the current directory or any of its parent directories"),
+				GlobalVersion:        "1.21.4",
+			},
+			expectedSteps: []expectedStep{
+				{kind: version.SourceVersionFile, error: "permission denied", selected: true},
+				{kind: version.SourceGlobal, semver: "1.21.4", version: versions[4]},
+			},
+		},
+		{
+			testTitle: "should return an error when no version applies to the current directory",
+			fileHelpers: &testutils.FakeFilesHelper{
+				FindModFileError: errors.New("go.mod or go.work file not found in the current directory or any of its parent directories"),
+			},
+			expectedError: errors.New("no version found for the current directory, use 'gvs local <version>' or 'gvs global <version>' to set one"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			t.Setenv(version.VersionEnvName, tc.env)
+
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(tc.fileHelpers, clientAPI, installer, log)
+
+			steps, err := versioner.ExplainVersion(versions, tc.query, false, false)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if len(steps) != len(tc.expectedSteps) {
+				t.Errorf("steps should be %d, instead got %d", len(tc.expectedSteps), len(steps))
+				return
+			}
+
+			for i, step := range steps {
+				expected := tc.expectedSteps[i]
+
+				if step.Source.Kind != expected.kind {
+					t.Errorf("step %d: kind should be %q, instead got %q", i, expected.kind, step.Source.Kind)
+				}
+
+				if step.Alias != expected.alias {
+					t.Errorf("step %d: alias should be %q, instead got %q", i, expected.alias, step.Alias)
+				}
+
+				semver := ""
+				if step.Semver != nil {
+					semver = step.Semver.GetVersion()
+				}
+
+				if semver != expected.semver {
+					t.Errorf("step %d: semver should be %q, instead got %q", i, expected.semver, semver)
+				}
+
+				if step.Version != expected.version {
+					t.Errorf("step %d: version should be %v, instead got %v", i, expected.version, step.Version)
+				}
+
+				stepError := ""
+				if step.Error != nil {
+					stepError = step.Error.Error()
+				}
+
+				if stepError != expected.error {
+					t.Errorf("step %d: error should be %q, instead got %q", i, expected.error, stepError)
+				}
+
+				if step.Selected != expected.selected {
+					t.Errorf("step %d: selected should be %t, instead got %t", i, expected.selected, step.Selected)
+				}
+			}
+		})
+	}
+}
@@ -7,11 +7,19 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
-	"strings"
 )
 
+// semverRegex describes the Go release version grammar.
+//
+// The version can optionally start with the `go` prefix, followed by the major version,
+// the minor version and either a patch version or a pre-release (beta or release candidate) number.
+// The minor version is required for the patch and the pre-release numbers.
+//
+// Examples: `1`, `1.21`, `1.21.3`, `go1.21.3`, `1.22beta1`, `go1.21rc2`.
+var semverRegex = regexp.MustCompile(`^(?:go)?(\d+)(?:\.(\d+)(?:\.(\d+)|(beta|rc)(\d+))?)?$`)
+
 // Semver contains the version split into semantic version structure.
-// Semver can have either a patch or a release candidate value, not both at the same time.
+// Semver can have either a patch or a pre-release (beta or release candidate) value, not both at the same time.
 type Semver struct {
 	// Contains the major version of the semantic structure.
 	Major *uint64
@@ -22,6 +30,9 @@ type Semver struct {
 	// Contains the patch version of the semantic structure.
 	Patch *uint64
 
+	// Contains the beta number of the semantic structure.
+	Beta *uint64
+
 	// Contains the release candidate number of the semantic structure.
 	ReleaseCandidate *uint64
 }
@@ -42,6 +53,8 @@ func (s Semver) GetVersion() string {
 
 	if s.Patch != nil {
 		semver += fmt.Sprintf(".%d", *s.Patch)
+	} else if s.Beta != nil {
+		semver += fmt.Sprintf("beta%d", *s.Beta)
 	} else if s.ReleaseCandidate != nil {
 		semver += fmt.Sprintf("rc%d", *s.ReleaseCandidate)
 	}
@@ -49,6 +62,130 @@ func (s Semver) GetVersion() string {
 	return semver
 }
 
+// IsPrerelease returns if the version is a beta or a release candidate.
+func (s Semver) IsPrerelease() bool {
+	return s.Beta != nil || s.ReleaseCandidate != nil
+}
+
+// getNumber returns the value of the given number, or the defaultValue if the number is nil.
+func getNumber(num *uint64, defaultValue int64) int64 {
+	if num == nil {
+		return defaultValue
+	}
+
+	return int64(*num)
+}
+
+// comparableParts returns the parts of the version in the order they should be compared.
+//
+// The parts follow the rules of the Go toolchain versions:
+//   - A missing minor version is the same as `0` (`1` is the same as `1.0.0`).
+//   - Before Go 1.21, a missing patch version is the same as `0` (`1.20` is the same as `1.20.0`).
+//   - Starting in Go 1.21, a missing patch version is lower than any pre-release and any patch
+//     version, since it represents the language version (`1.21` < `1.21rc1` < `1.21.0`).
+//   - A pre-release is lower than the release (`1.20beta1` < `1.20rc1` < `1.20`).
+//
+// Missing values are represented as -1.
+func (s Semver) comparableParts() [5]int64 {
+	major := getNumber(s.Major, 0)
+	minor := getNumber(s.Minor, 0)
+	patch := getNumber(s.Patch, -1)
+
+	if s.Minor == nil || (patch == -1 && !s.IsPrerelease() && minor < 21) {
+		patch = 0
+	}
+
+	// the kind of the pre-release, where the releases do not have a kind.
+	// Since the releases of Go 1.21 and later always have a patch version, the missing kind
+	// makes a difference only against the language versions (e.g. `1.21`).
+	kind := int64(-1)
+	pre := int64(-1)
+	if s.Beta != nil {
+		kind = 0
+		pre = int64(*s.Beta)
+	} else if s.ReleaseCandidate != nil {
+		kind = 1
+		pre = int64(*s.ReleaseCandidate)
+	}
+
+	return [5]int64{major, minor, patch, kind, pre}
+}
+
+// Compare returns an integer comparing two versions, based on the Go toolchain version ordering.
+// The result will be 0 if s == other, -1 if s < other, and +1 if s > other.
+//
+// For example, the versions below are in increasing order:
+//
+//	1.20rc1 < 1.20 == 1.20.0 < 1.20.1 < 1.21 < 1.21rc1 < 1.21.0 < 1.22beta1 < 1.22rc1 < 1.22.0
+func (s Semver) Compare(other Semver) int {
+	a := s.comparableParts()
+	b := other.comparableParts()
+
+	for i := range a {
+		if a[i] < b[i] {
+			return -1
+		}
+
+		if a[i] > b[i] {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// getPatch returns the patch version that is used when matching versions.
+//
+// Before Go 1.21, the first release of a minor version did not have a patch version (e.g. `1.20`),
+// which is the same as `1.20.0`. For any other version without a patch version, getPatch returns nil.
+func (s Semver) getPatch() *uint64 {
+	if s.Patch != nil || s.IsPrerelease() || s.Minor == nil || *s.Minor >= 21 {
+		return s.Patch
+	}
+
+	var zero uint64 = 0
+	return &zero
+}
+
+// equalNumbers returns if both numbers are nil or if they have the same value.
+func equalNumbers(a *uint64, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return *a == *b
+}
+
+// Includes returns if the given version is described by the semver.
+//
+// Only the parts that are specified in the semver are compared, which means that a partial
+// version describes a whole release line: `1.21` includes `1.21.0`, `1.21.3` and `1.21rc2`,
+// while `1` includes every `1.x` version.
+// A semver with a patch or a pre-release number describes only that exact version.
+func (s Semver) Includes(version Semver) bool {
+	if s.Major == nil || !equalNumbers(s.Major, version.Major) {
+		return false
+	}
+
+	if s.Minor == nil {
+		return true
+	}
+
+	if !equalNumbers(s.Minor, version.Minor) {
+		return false
+	}
+
+	if s.Patch != nil {
+		return !version.IsPrerelease() && equalNumbers(s.getPatch(), version.getPatch())
+	}
+
+	if s.IsPrerelease() {
+		return equalNumbers(s.Beta, version.Beta) && equalNumbers(s.ReleaseCandidate, version.ReleaseCandidate)
+	}
+
+	return true
+}
+
 // parseNumber converts a string into *uint64.
 // In case of an error while converting the value, parseNumber return nil.
 func parseNumber(str string) *uint64 {
@@ -63,36 +200,41 @@ func parseNumber(str string) *uint64 {
 
 // ParseSemver parses the given stringified version into a semantic version structure.
 //
+// The version should follow the Go release version grammar, optionally prefixed with `go`
+// (e.g. `1.21`, `1.21.3`, `go1.21.3`, `1.22beta1`, `1.21rc2`).
+// Any other text, before or after the version, makes the version invalid.
+//
 // If the parse is successful it will store it
 // in the value pointed to by semver.
 //
 // If the parse fails, ParseSemver will return an error.
 func ParseSemver(version string, semver *Semver) error {
-	var major *uint64
-	var minor *uint64
-	var patch *uint64
-	var rc *uint64
-
-	r := regexp.MustCompile(`(\d{1,2})(\.?\d{1,2})?(\.?\d{1,2}|rc\d{1,2})?`)
-
-	if !r.MatchString(version) {
+	groups := semverRegex.FindStringSubmatch(version)
+	if groups == nil {
 		return errors.New("invalid Go version")
 	}
 
-	groups := r.FindStringSubmatch(version)[1:]
-	major = parseNumber(groups[0])
-	minor = parseNumber(strings.TrimPrefix(groups[1], "."))
+	groups = groups[1:]
 
-	if strings.HasPrefix(groups[2], "rc") {
-		rc = parseNumber(strings.TrimPrefix(groups[2], "rc"))
-	} else {
-		patch = parseNumber(strings.TrimPrefix(groups[2], "."))
+	// the numbers might overflow even if they match the grammar
+	for i, group := range groups {
+		if i != 3 && group != "" && parseNumber(group) == nil {
+			return errors.New("invalid Go version")
+		}
 	}
 
-	semver.Major = major
-	semver.Minor = minor
-	semver.Patch = patch
-	semver.ReleaseCandidate = rc
+	semver.Major = parseNumber(groups[0])
+	semver.Minor = parseNumber(groups[1])
+	semver.Patch = parseNumber(groups[2])
+	semver.Beta = nil
+	semver.ReleaseCandidate = nil
+
+	switch groups[3] {
+	case "beta":
+		semver.Beta = parseNumber(groups[4])
+	case "rc":
+		semver.ReleaseCandidate = parseNumber(groups[4])
+	}
 
 	return nil
 }
@@ -2,6 +2,7 @@ package version_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/VassilisPallas/gvs/version"
@@ -50,6 +51,66 @@ func TestParseSemver(t *testing.T) {
 			expectedSemver: &version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(23), ReleaseCandidate: intToUnsigned(2)},
 			expectedError:  nil,
 		},
+		{
+			testTitle:      "should parse beta version",
+			version:        "1.22beta1",
+			expectedSemver: &version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(22), Beta: intToUnsigned(1)},
+			expectedError:  nil,
+		},
+		{
+			testTitle:      "should parse version with the go prefix",
+			version:        "go1.21.3",
+			expectedSemver: &version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(21), Patch: intToUnsigned(3)},
+			expectedError:  nil,
+		},
+		{
+			testTitle:      "should parse beta version with the go prefix",
+			version:        "go1.22beta1",
+			expectedSemver: &version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(22), Beta: intToUnsigned(1)},
+			expectedError:  nil,
+		},
+		{
+			testTitle:      "should return an error when the version contains text before the version",
+			version:        "version1.21.3",
+			expectedSemver: &version.Semver{},
+			expectedError:  errors.New("invalid Go version"),
+		},
+		{
+			testTitle:      "should return an error when the version contains text after the version",
+			version:        "1.21.3-foo",
+			expectedSemver: &version.Semver{},
+			expectedError:  errors.New("invalid Go version"),
+		},
+		{
+			testTitle:      "should return an error when the version has too many parts",
+			version:        "1.21.3.1",
+			expectedSemver: &version.Semver{},
+			expectedError:  errors.New("invalid Go version"),
+		},
+		{
+			testTitle:      "should return an error when a patch version has a pre-release",
+			version:        "1.21.3rc1",
+			expectedSemver: &version.Semver{},
+			expectedError:  errors.New("invalid Go version"),
+		},
+		{
+			testTitle:      "should return an error when the pre-release does not have a minor version",
+			version:        "1rc1",
+			expectedSemver: &version.Semver{},
+			expectedError:  errors.New("invalid Go version"),
+		},
+		{
+			testTitle:      "should return an error when the pre-release kind is unknown",
+			version:        "1.21alpha1",
+			expectedSemver: &version.Semver{},
+			expectedError:  errors.New("invalid Go version"),
+		},
+		{
+			testTitle:      "should return an error when the number overflows",
+			version:        "1.99999999999999999999999",
+			expectedSemver: &version.Semver{},
+			expectedError:  errors.New("invalid Go version"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -105,6 +166,11 @@ func TestGetVersion(t *testing.T) {
 			semver:          &version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(23), ReleaseCandidate: intToUnsigned(2)},
 			expectedVersion: "1.23rc2",
 		},
+		{
+			testTitle:       "should return the major, minor and the beta version",
+			semver:          &version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(22), Beta: intToUnsigned(1)},
+			expectedVersion: "1.22beta1",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -117,3 +183,114 @@ func TestGetVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "1.21.3", b: "1.21.3", expected: 0},
+		{a: "go1.21.3", b: "1.21.3", expected: 0},
+		{a: "1.21.3", b: "1.21.2", expected: 1},
+		{a: "1.21.2", b: "1.21.10", expected: -1},
+		{a: "1.9.2", b: "1.10", expected: -1},
+		{a: "1.21.0", b: "1.20.12", expected: 1},
+		{a: "2.0.0", b: "1.30.0", expected: 1},
+		{a: "1.20", b: "1.20.0", expected: 0},
+		{a: "1.20", b: "1.20.1", expected: -1},
+		{a: "1.20rc1", b: "1.20", expected: -1},
+		{a: "1.20beta1", b: "1.20rc1", expected: -1},
+		{a: "1.20rc2", b: "1.20rc1", expected: 1},
+		{a: "1.21", b: "1.21rc1", expected: -1},
+		{a: "1.21rc1", b: "1.21.0", expected: -1},
+		{a: "1.21", b: "1.21.0", expected: -1},
+		{a: "1.22beta1", b: "1.21.5", expected: 1},
+		{a: "1", b: "1.0.0", expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s compared to %s", tc.a, tc.b), func(t *testing.T) {
+			a := &version.Semver{}
+			if err := version.ParseSemver(tc.a, a); err != nil {
+				t.Fatalf("error should be nil, instead got %q", err.Error())
+			}
+
+			b := &version.Semver{}
+			if err := version.ParseSemver(tc.b, b); err != nil {
+				t.Fatalf("error should be nil, instead got %q", err.Error())
+			}
+
+			if res := a.Compare(*b); res != tc.expected {
+				t.Errorf("result should be %d, instead got %d", tc.expected, res)
+			}
+
+			if res := b.Compare(*a); res != -tc.expected {
+				t.Errorf("reversed result should be %d, instead got %d", -tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestIsPrerelease(t *testing.T) {
+	testCases := []struct {
+		version  string
+		expected bool
+	}{
+		{version: "1.21.3", expected: false},
+		{version: "1.21", expected: false},
+		{version: "1.21rc2", expected: true},
+		{version: "1.22beta1", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.version, func(t *testing.T) {
+			semver := &version.Semver{}
+			if err := version.ParseSemver(tc.version, semver); err != nil {
+				t.Fatalf("error should be nil, instead got %q", err.Error())
+			}
+
+			if res := semver.IsPrerelease(); res != tc.expected {
+				t.Errorf("result should be %t, instead got %t", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestIncludes(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{a: "1", b: "1.21.3", expected: true},
+		{a: "1", b: "2.0.0", expected: false},
+		{a: "1.21", b: "1.21.3", expected: true},
+		{a: "1.21", b: "1.21rc2", expected: true},
+		{a: "1.21", b: "1.22.0", expected: false},
+		{a: "1.21.3", b: "1.21.3", expected: true},
+		{a: "1.21.3", b: "1.21.30", expected: false},
+		{a: "1.20.0", b: "1.20", expected: true},
+		{a: "1.21.0", b: "1.21", expected: false},
+		{a: "1.21rc2", b: "1.21rc2", expected: true},
+		{a: "1.21rc2", b: "1.21.0", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s includes %s", tc.a, tc.b), func(t *testing.T) {
+			a := &version.Semver{}
+			if err := version.ParseSemver(tc.a, a); err != nil {
+				t.Fatalf("error should be nil, instead got %q", err.Error())
+			}
+
+			b := &version.Semver{}
+			if err := version.ParseSemver(tc.b, b); err != nil {
+				t.Fatalf("error should be nil, instead got %q", err.Error())
+			}
+
+			if res := a.Includes(*b); res != tc.expected {
+				t.Errorf("result should be %t, instead got %t", tc.expected, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+// EnableShims replaces the symlinks of the `go` and the `gofmt` binaries with shims that run the given gvs executable,
+// so the version is resolved every time the tools run, based on the current directory and the environment.
+//
+// If autoInstall is true, the shims install the resolved version first, if it's not already installed.
+//
+// If the shims can't be created, EnableShims returns back an error.
+func (v Version) EnableShims(executable string, autoInstall bool) error {
+	return v.fileHelpers.CreateShims(executable, autoInstall)
+}
+
+// DisableShims removes the shims and creates again the symlinks for the currently used version (if any).
+//
+// If the shims can't be removed or the symlinks can't be created, DisableShims returns back an error.
+func (v Version) DisableShims() error {
+	if err := v.fileHelpers.RemoveShims(); err != nil {
+		return err
+	}
+
+	currentVersion := v.fileHelpers.GetRecentVersion()
+	if currentVersion == "" {
+		return nil
+	}
+
+	return v.fileHelpers.CreateExecutableSymlink(currentVersion)
+}
+
+// AreShimsEnabled returns if the shims are used instead of the symlinks.
+func (v Version) AreShimsEnabled() bool {
+	return v.fileHelpers.AreShimsEnabled()
+}
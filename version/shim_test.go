@@ -0,0 +1,95 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+)
+
+func TestEnableShims(t *testing.T) {
+	fileHelpers := &testutils.FakeFilesHelper{}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	if err := versioner.EnableShims("/usr/local/bin/gvs", true); err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	if !versioner.AreShimsEnabled() {
+		t.Error("shims should be enabled")
+	}
+
+	if fileHelpers.ShimsExecutable != "/usr/local/bin/gvs" {
+		t.Errorf("executable should be %q, instead got %q", "/usr/local/bin/gvs", fileHelpers.ShimsExecutable)
+	}
+
+	if !fileHelpers.ShimsAutoInstall {
+		t.Error("shims should install the versions")
+	}
+}
+
+func TestDisableShims(t *testing.T) {
+	testCases := []struct {
+		testTitle             string
+		recentVersion         string
+		removeShimsError      error
+		expectedSymlinkCalled bool
+		expectedError         error
+	}{
+		{
+			testTitle:             "should create the symlinks of the current version",
+			recentVersion:         "go1.21.5",
+			expectedSymlinkCalled: true,
+		},
+		{
+			testTitle:             "should not create the symlinks when there is no current version",
+			recentVersion:         "",
+			expectedSymlinkCalled: false,
+		},
+		{
+			testTitle:             "should return an error when the shims can't be removed",
+			recentVersion:         "go1.21.5",
+			removeShimsError:      errors.New("permission denied"),
+			expectedSymlinkCalled: false,
+			expectedError:         errors.New("permission denied"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{
+				ShimsEnabled:     true,
+				RecentVersion:    tc.recentVersion,
+				RemoveShimsError: tc.removeShimsError,
+			}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			err := versioner.DisableShims()
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if fileHelpers.CreateExecutableSymlinkCalled != tc.expectedSymlinkCalled {
+				t.Errorf("CreateExecutableSymlink called should be %t, instead got %t", tc.expectedSymlinkCalled, fileHelpers.CreateExecutableSymlinkCalled)
+			}
+		})
+	}
+}
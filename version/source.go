@@ -0,0 +1,266 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import (
+	"fmt"
+	"os"
+)
+
+// VersionEnvName is the name of the environment variable that overrides the version of the current directory.
+const VersionEnvName = "GVS_VERSION"
+
+const (
+	// SourceFlag is the kind of the version that is passed on the command line.
+	SourceFlag = "flag"
+
+	// SourceEnv is the kind of the version that is found on the GVS_VERSION environment variable.
+	SourceEnv = "env"
+
+	// SourceVersionFile is the kind of the version that is found on a `.go-version` or a `.tool-versions` file.
+	SourceVersionFile = "version-file"
+
+	// SourceModFile is the kind of the version that is found on a go.work or a go.mod file.
+	SourceModFile = "mod-file"
+
+	// SourceGlobal is the kind of the global default version.
+	SourceGlobal = "global"
+)
+
+// VersionSource describes where a version was found.
+type VersionSource struct {
+	// Kind is the kind of the source (SourceFlag, SourceEnv, SourceVersionFile, SourceModFile or SourceGlobal).
+	Kind string
+
+	// Path is the path of the file the version was found on. It is empty for the command line and the environment variable.
+	Path string
+
+	// Directive is the go.mod directive the version was found on (`go` or `toolchain`). It is empty for the other kinds.
+	Directive string
+
+	// Query is the version as it was found, which can be anything that can be resolved (e.g. `1.21.6`, `~1.21`, `latest`).
+	Query string
+
+	// Error is the reason the version of the source can't be read (e.g. a go.mod file that can't be parsed).
+	// It is set only on the sources of ExplainVersion, since the rest of the lookups stop on the error instead.
+	Error error
+}
+
+// String returns back a description of the source (e.g. `/home/user/project/.go-version`).
+func (s VersionSource) String() string {
+	switch s.Kind {
+	case SourceFlag:
+		return "the command line"
+	case SourceEnv:
+		return fmt.Sprintf("the %s environment variable", VersionEnvName)
+	case SourceModFile:
+		if s.Directive == "" {
+			return s.Path
+		}
+
+		return fmt.Sprintf("the %q directive of %s", s.Directive, s.Path)
+	case SourceGlobal:
+		if s.Path == "" {
+			return "the global default version"
+		}
+
+		return fmt.Sprintf("the global default version (%s)", s.Path)
+	default:
+		if s.Path == "" {
+			return "the version file"
+		}
+
+		return s.Path
+	}
+}
+
+// GetModVersion returns the version of the go.work or the go.mod file that applies to the current directory.
+//
+// The version is selected with GetModVersionQuery, where latestPatch selects the latest patch version
+// of the directive, instead of the exact version.
+//
+// If no file is found, or the versions of the file are not valid, GetModVersion returns back an error.
+func (v Version) GetModVersion(latestPatch bool) (*VersionSource, error) {
+	path, err := v.fileHelpers.FindModFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return v.readModVersion(path, latestPatch)
+}
+
+// readModVersion returns the version of the given go.work or go.mod file.
+func (v Version) readModVersion(path string, latestPatch bool) (*VersionSource, error) {
+	goVersions, toolchains, err := v.fileHelpers.ReadVersionFromMod(path)
+	if err != nil {
+		return nil, err
+	}
+
+	query, directive, err := GetModVersionQuery(goVersions, toolchains, latestPatch)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &VersionSource{Kind: SourceModFile, Path: path, Directive: directive, Query: query}, nil
+}
+
+// FindProjectVersion returns the version that applies to the current directory, along with where it was found.
+//
+// The version is looked up in the order below, and the first one that is found is returned:
+//  1. The GVS_VERSION environment variable.
+//  2. The closest `.go-version` or `.tool-versions` file, from the current directory up to the root of the repository.
+//  3. The closest go.work or go.mod file (see GetModVersion).
+//  4. The global default version (see SetGlobalVersion).
+//
+// If no version is found, or any of the files can't be read, FindProjectVersion returns back an error.
+func (v Version) FindProjectVersion(latestPatch bool) (*VersionSource, error) {
+	sources, err := v.findVersionSources(latestPatch, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sources) == 0 {
+		return nil, noProjectVersionError()
+	}
+
+	return sources[0], nil
+}
+
+// noProjectVersionError returns the error for a directory that no version applies to.
+func noProjectVersionError() error {
+	return fmt.Errorf("no version found for the current directory, use 'gvs local <version>' or 'gvs global <version>' to set one")
+}
+
+// GetVersionSources returns all the versions that apply to the current directory, in the order FindProjectVersion
+// looks them up, so the first one is the version that is used and the rest are overridden by it.
+//
+// If any of the files can't be read, GetVersionSources returns back an error.
+func (v Version) GetVersionSources(latestPatch bool) ([]*VersionSource, error) {
+	return v.findVersionSources(latestPatch, false, false)
+}
+
+// findVersionSources returns the versions that apply to the current directory, in the order they are looked up.
+//
+// If firstOnly is true, the lookup stops on the first version that is found, so the sources after it
+// are not read at all (e.g. an invalid go.mod file doesn't matter when the GVS_VERSION environment variable is set).
+// If keepInvalid is true, the sources that can't be read are returned with their error, instead of stopping the lookup.
+func (v Version) findVersionSources(latestPatch bool, firstOnly bool, keepInvalid bool) ([]*VersionSource, error) {
+	sources := []*VersionSource{}
+	done := func() bool {
+		return firstOnly && len(sources) > 0
+	}
+
+	if query := os.Getenv(VersionEnvName); query != "" {
+		sources = append(sources, &VersionSource{Kind: SourceEnv, Query: query})
+	}
+
+	if done() {
+		return sources, nil
+	}
+
+	path, query, err := v.fileHelpers.FindVersionFile()
+	if err != nil {
+		if !keepInvalid {
+			return nil, err
+		}
+
+		sources = append(sources, &VersionSource{Kind: SourceVersionFile, Path: path, Error: err})
+	} else if query != "" {
+		sources = append(sources, &VersionSource{Kind: SourceVersionFile, Path: path, Query: query})
+	}
+
+	if done() {
+		return sources, nil
+	}
+
+	// the missing go.mod file is not an error, since the global version can still be used.
+	if modPath, err := v.fileHelpers.FindModFile(); err == nil {
+		modVersion, err := v.readModVersion(modPath, latestPatch)
+		if err != nil {
+			if !keepInvalid {
+				return nil, err
+			}
+
+			modVersion = &VersionSource{Kind: SourceModFile, Path: modPath, Error: err}
+		}
+
+		sources = append(sources, modVersion)
+	}
+
+	if done() {
+		return sources, nil
+	}
+
+	globalVersion, err := v.GetGlobalVersion()
+	if err != nil {
+		if !keepInvalid {
+			return nil, err
+		}
+
+		globalVersion = &VersionSource{Kind: SourceGlobal, Error: err}
+	}
+
+	if globalVersion != nil {
+		sources = append(sources, globalVersion)
+	}
+
+	return sources, nil
+}
+
+// GetGlobalVersion returns the global default version, or nil if there is no global default version.
+//
+// If the file of the global default version can't be read, GetGlobalVersion returns back an error.
+func (v Version) GetGlobalVersion() (*VersionSource, error) {
+	path, query, err := v.fileHelpers.GetGlobalVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	if query == "" {
+		return nil, nil
+	}
+
+	return &VersionSource{Kind: SourceGlobal, Path: path, Query: query}, nil
+}
+
+// SetLocalVersion writes the given version to the `.go-version` file of the current directory, and returns the path of the file.
+//
+// If the version is not valid, or the file can't be written, SetLocalVersion returns back an error.
+func (v Version) SetLocalVersion(query string) (string, error) {
+	if err := v.validateVersionFileQuery(query); err != nil {
+		return "", err
+	}
+
+	return v.fileHelpers.WriteLocalVersion(query)
+}
+
+// SetGlobalVersion writes the given version as the global default version, and returns the path of the file.
+// The global default version is used when no other version applies to the current directory.
+//
+// If the version is not valid, or the file can't be written, SetGlobalVersion returns back an error.
+func (v Version) SetGlobalVersion(query string) (string, error) {
+	if err := v.validateVersionFileQuery(query); err != nil {
+		return "", err
+	}
+
+	return v.fileHelpers.WriteGlobalVersion(query)
+}
+
+// validateVersionFileQuery returns an error if the query can't be written to a version file,
+// which means it's neither an alias, a keyword, a constraint expression nor a version.
+func (v Version) validateVersionFileQuery(query string) error {
+	aliases, err := v.fileHelpers.GetAliases()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := aliases[query]; ok {
+		return nil
+	}
+
+	if err := validateQuery(query); err != nil {
+		return fmt.Errorf("%q is not a valid version: %w", query, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindProjectVersion(t *testing.T) {
+	testCases := []struct {
+		testTitle      string
+		env            string
+		fileHelpers    *testutils.FakeFilesHelper
+		latestPatch    bool
+		expectedSource *version.VersionSource
+		expectedError  error
+	}{
+		{
+			testTitle: "should return the version of the environment variable",
+			env:       "1.20",
+			fileHelpers: &testutils.FakeFilesHelper{
+				VersionFile:        "/project/.go-version",
+				VersionFileVersion: "1.21.5",
+			},
+			expectedSource: &version.VersionSource{Kind: version.SourceEnv, Query: "1.20"},
+		},
+		{
+			testTitle: "should return the version of the version file",
+			fileHelpers: &testutils.FakeFilesHelper{
+				VersionFile:        "/project/.go-version",
+				VersionFileVersion: "1.21.5",
+				ModFile:            "/project/go.mod",
+				ModGoVersions:      []string{"1.20"},
+				GlobalVersion:      "1.19",
+			},
+			expectedSource: &version.VersionSource{Kind: version.SourceVersionFile, Path: "/project/.go-version", Query: "1.21.5"},
+		},
+		{
+			testTitle: "should return the version of the go.mod file",
+			fileHelpers: &testutils.FakeFilesHelper{
+				ModFile:       "/project/go.mod",
+				ModGoVersions: []string{"1.21"},
+				GlobalVersion: "1.19",
+			},
+			expectedSource: &version.VersionSource{Kind: version.SourceModFile, Path: "/project/go.mod", Directive: version.ModDirectiveGo, Query: "1.21.0"},
+		},
+		{
+			testTitle: "should return the latest patch version of the go.mod file",
+			fileHelpers: &testutils.FakeFilesHelper{
+				ModFile:       "/project/go.mod",
+				ModGoVersions: []string{"1.21.4"},
+			},
+			latestPatch:    true,
+			expectedSource: &version.VersionSource{Kind: version.SourceModFile, Path: "/project/go.mod", Directive: version.ModDirectiveGo, Query: "1.21"},
+		},
+		{
+			testTitle: "should return the global version",
+			fileHelpers: &testutils.FakeFilesHelper{
+				FindModFileError: errors.New("go.mod or go.work file not found in the current directory or any of its parent directories"),
+				GlobalVersion:    "1.19",
+			},
+			expectedSource: &version.VersionSource{Kind: version.SourceGlobal, Path: "/home/.gvs/.go-version", Query: "1.19"},
+		},
+		{
+			testTitle: "should return an error when the version file can't be read",
+			fileHelpers: &testutils.FakeFilesHelper{
+				FindVersionFileError: errors.New("permission denied"),
+			},
+			expectedError: errors.New("permission denied"),
+		},
+		{
+			testTitle: "should return an error when the go.mod file can't be read",
+			fileHelpers: &testutils.FakeFilesHelper{
+				ModFile:                 "/project/go.mod",
+				ReadVersionFromModError: errors.New("/project/go.mod does not contain a go or a toolchain directive"),
+				GlobalVersion:           "1.19",
+			},
+			expectedError: errors.New("/project/go.mod does not contain a go or a toolchain directive"),
+		},
+		{
+			testTitle: "should return an error when no version is found",
+			fileHelpers: &testutils.FakeFilesHelper{
+				FindModFileError: errors.New("go.mod or go.work file not found in the current directory or any of its parent directories"),
+			},
+			expectedError: errors.New("no version found for the current directory, use 'gvs local <version>' or 'gvs global <version>' to set one"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			t.Setenv(version.VersionEnvName, tc.env)
+
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(tc.fileHelpers, clientAPI, installer, log)
+
+			source, err := versioner.FindProjectVersion(tc.latestPatch)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(source, tc.expectedSource) {
+				t.Errorf("Wrong source received, got=%s", cmp.Diff(source, tc.expectedSource))
+			}
+		})
+	}
+}
+
+func TestSetLocalVersion(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		query           string
+		writeError      error
+		expectedPath    string
+		expectedVersion string
+		expectedError   error
+	}{
+		{
+			testTitle:       "should write a version",
+			query:           "1.21.5",
+			expectedPath:    "/project/.go-version",
+			expectedVersion: "1.21.5",
+		},
+		{
+			testTitle:       "should write a constraint",
+			query:           "~1.21",
+			expectedPath:    "/project/.go-version",
+			expectedVersion: "~1.21",
+		},
+		{
+			testTitle:       "should write an alias",
+			query:           "work",
+			expectedPath:    "/project/.go-version",
+			expectedVersion: "work",
+		},
+		{
+			testTitle:     "should return an error when the version is not valid",
+			query:         "home",
+			expectedError: errors.New(`"home" is not a valid version: invalid Go version`),
+		},
+		{
+			testTitle:     "should return an error when the file can't be written",
+			query:         "1.21.5",
+			writeError:    errors.New("an error occurred while writing to the file"),
+			expectedError: errors.New("an error occurred while writing to the file"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{
+				Aliases:           map[string]string{"work": "1.21.6"},
+				WriteVersionError: tc.writeError,
+			}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			path, err := versioner.SetLocalVersion(tc.query)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if path != tc.expectedPath {
+				t.Errorf("path should be %q, instead got %q", tc.expectedPath, path)
+			}
+
+			if fileHelpers.LocalVersion != tc.expectedVersion {
+				t.Errorf("version should be %q, instead got %q", tc.expectedVersion, fileHelpers.LocalVersion)
+			}
+		})
+	}
+}
+
+func TestSetGlobalVersion(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		query           string
+		expectedVersion string
+		expectedError   error
+	}{
+		{
+			testTitle:       "should write a keyword",
+			query:           "latest",
+			expectedVersion: "latest",
+		},
+		{
+			testTitle:     "should return an error when the constraint is not valid",
+			query:         ">=1.x",
+			expectedError: errors.New(`">=1.x" is not a valid version: invalid version "1.x" in constraint ">=1.x"`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			_, err := versioner.SetGlobalVersion(tc.query)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if fileHelpers.GlobalVersion != tc.expectedVersion {
+				t.Errorf("version should be %q, instead got %q", tc.expectedVersion, fileHelpers.GlobalVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+// SupportStatus describes if a version is supported by the Go team, which means that it still gets security fixes.
+type SupportStatus int
+
+const (
+	// SupportUnknown means that the support status is not computed, because the available versions are not known
+	// (e.g. for the versions that are read only from the installed directories).
+	SupportUnknown SupportStatus = iota
+
+	// Supported means that the version belongs to one of the two latest release lines.
+	Supported
+
+	// Unsupported means that the version belongs to an older release line, which doesn't get security fixes anymore.
+	Unsupported
+)
+
+// String returns the name of the status, which is printed to the cli.
+func (s SupportStatus) String() string {
+	switch s {
+	case Supported:
+		return "supported"
+	case Unsupported:
+		return "unsupported"
+	default:
+		return "unknown"
+	}
+}
+
+// findOldestSupportedLine returns the oldest release line that is supported by the Go team (see FindSupportedVersions),
+// or nil if there are no stable versions.
+func findOldestSupportedLine(evs []*ExtendedVersion) *Semver {
+	supportedVersions := FindSupportedVersions(evs)
+	if len(supportedVersions) == 0 {
+		return nil
+	}
+
+	semver, _ := supportedVersions[len(supportedVersions)-1].getSemver()
+	line := getReleaseLine(semver)
+
+	return &line
+}
+
+// getSupportStatus returns the support status of the given version, where oldestSupportedLine is the oldest release line that is supported.
+// The pre-releases of a release line that is newer than the supported ones are supported, since they are not released yet.
+func getSupportStatus(semver *Semver, oldestSupportedLine *Semver) SupportStatus {
+	if oldestSupportedLine == nil {
+		return SupportUnknown
+	}
+
+	line := getReleaseLine(semver)
+	if line.Compare(*oldestSupportedLine) >= 0 {
+		return Supported
+	}
+
+	return Unsupported
+}
+
+// setSupportStatus updates the support status of the given versions, based on the two latest release lines of the versions.
+func setSupportStatus(evs []*ExtendedVersion) {
+	oldestSupportedLine := findOldestSupportedLine(evs)
+
+	for _, ev := range evs {
+		semver, err := ev.getSemver()
+		if err != nil {
+			continue
+		}
+
+		ev.SupportStatus = getSupportStatus(semver, oldestSupportedLine)
+	}
+}
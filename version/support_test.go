@@ -0,0 +1,97 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/VassilisPallas/gvs/api_client"
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSupportStatusString(t *testing.T) {
+	testCases := []struct {
+		status   version.SupportStatus
+		expected string
+	}{
+		{status: version.SupportUnknown, expected: "unknown"},
+		{status: version.Supported, expected: "supported"},
+		{status: version.Unsupported, expected: "unsupported"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			if res := tc.status.String(); res != tc.expected {
+				t.Errorf("result should be %q, instead got %q", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestGetVersionsSetsSupportStatus(t *testing.T) {
+	fileHelpers := &testutils.FakeFilesHelper{}
+	clientAPI := testutils.FakeGoClientAPI{
+		FetchedVersions: []api_client.VersionInfo{
+			{Version: "go1.23rc1", IsStable: false},
+			{Version: "go1.22.1", IsStable: true},
+			{Version: "go1.22rc1", IsStable: false},
+			{Version: "go1.21.5", IsStable: true},
+			{Version: "go1.20.12", IsStable: true},
+			{Version: "go1.19.13", IsStable: true},
+		},
+	}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	versions, err := versioner.GetVersions(true)
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	statuses := map[string]version.SupportStatus{}
+	for _, ev := range versions {
+		statuses[ev.Version] = ev.SupportStatus
+	}
+
+	expected := map[string]version.SupportStatus{
+		"go1.23rc1": version.Supported,
+		"go1.22.1":  version.Supported,
+		"go1.22rc1": version.Supported,
+		"go1.21.5":  version.Supported,
+		"go1.20.12": version.Unsupported,
+		"go1.19.13": version.Unsupported,
+	}
+
+	if !cmp.Equal(statuses, expected) {
+		t.Errorf("Wrong statuses received, got=%s", cmp.Diff(statuses, expected))
+	}
+}
+
+func TestGetInstalledVersionsUnknownSupportStatus(t *testing.T) {
+	fileHelpers := &testutils.FakeFilesHelper{InstalledVersions: []string{"go1.22.1", "go1.19.13"}}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	versions, err := versioner.GetInstalledVersions()
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	if len(versions) != 2 {
+		t.Errorf("versions should be 2, instead got %d", len(versions))
+	}
+
+	for _, ev := range versions {
+		if ev.SupportStatus != version.SupportUnknown {
+			t.Errorf("support status of %s should be %s, instead got %s", ev.Version, version.SupportUnknown, ev.SupportStatus)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package version provides an interface to make handle
+// the CLI logic for the versions.
+package version
+
+import (
+	"fmt"
+
+	"github.com/VassilisPallas/gvs/errors"
+)
+
+// FindUpgradeVersion returns the newest stable patch version of the release line of the currently used version
+// (e.g. `1.21.5` when the current version is `1.21.3`). If minor is true, the newest stable version of any release line
+// is returned instead, so the upgrade can move to a newer minor version.
+//
+// If the current version is already the newest one, FindUpgradeVersion returns back nil.
+//
+// If there is no used version, FindUpgradeVersion will return an error of the type *NoInstalledVersionsError.
+// If the current version is not valid, or no stable version is found, FindUpgradeVersion returns back an error.
+func (v Version) FindUpgradeVersion(evs []*ExtendedVersion, minor bool) (*ExtendedVersion, error) {
+	currentVersion := v.GetCurrentVersion()
+	if currentVersion == "" {
+		return nil, &errors.NoInstalledVersionsError{}
+	}
+
+	currentSemver := &Semver{}
+	if err := ParseSemver(currentVersion, currentSemver); err != nil {
+		return nil, err
+	}
+
+	currentLine := getReleaseLine(currentSemver)
+
+	found := findNewestVersion(evs, func(semver *Semver) bool {
+		return !semver.IsPrerelease() && (minor || currentLine.Includes(*semver))
+	})
+	if found == nil {
+		return nil, fmt.Errorf("no stable version found for %s", currentLine.GetVersion())
+	}
+
+	foundSemver, _ := found.getSemver()
+	if foundSemver.Compare(*currentSemver) <= 0 {
+		return nil, nil
+	}
+
+	return found, nil
+}
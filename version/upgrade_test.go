@@ -0,0 +1,87 @@
+package version_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VassilisPallas/gvs/internal/testutils"
+	"github.com/VassilisPallas/gvs/logger"
+	"github.com/VassilisPallas/gvs/version"
+)
+
+func TestFindUpgradeVersion(t *testing.T) {
+	versions := getConstraintVersions()
+
+	testCases := []struct {
+		testTitle         string
+		recentVersion     string
+		minor             bool
+		expecectedVersion *version.ExtendedVersion
+		expectedError     error
+	}{
+		{
+			testTitle:         "should return the newest patch of the current minor version",
+			recentVersion:     "go1.21.3",
+			expecectedVersion: versions[3],
+		},
+		{
+			testTitle:         "should return nil when the current version is the newest patch",
+			recentVersion:     "go1.21.5",
+			expecectedVersion: nil,
+		},
+		{
+			testTitle:         "should return the newest stable version for minor upgrades",
+			recentVersion:     "go1.21.3",
+			minor:             true,
+			expecectedVersion: versions[1],
+		},
+		{
+			testTitle:         "should return nil when the current version is the newest stable version for minor upgrades",
+			recentVersion:     "go1.22.1",
+			minor:             true,
+			expecectedVersion: nil,
+		},
+		{
+			testTitle:         "should return the newest stable patch when the current version is a pre-release",
+			recentVersion:     "go1.22rc2",
+			expecectedVersion: versions[1],
+		},
+		{
+			testTitle:     "should return an error when there is no current version",
+			recentVersion: "",
+			expectedError: errors.New("there is no any installed version"),
+		},
+		{
+			testTitle:     "should return an error when the current minor version has no stable version",
+			recentVersion: "go1.23rc1",
+			expectedError: errors.New("no stable version found for 1.23"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{RecentVersion: tc.recentVersion}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			ev, err := versioner.FindUpgradeVersion(versions, tc.minor)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if ev != tc.expecectedVersion {
+				t.Errorf("version should be %v, instead got %v", tc.expecectedVersion, ev)
+			}
+		})
+	}
+}
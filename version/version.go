@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/VassilisPallas/gvs/api_client"
@@ -22,6 +23,65 @@ type Versioner interface {
 	// FetchVersions must return a slice with the versions a non-null error.
 	GetVersions(forceFetchVersions bool) ([]*ExtendedVersion, error)
 
+	// RefreshVersions fetches the versions from the API, and returns them together with the versions that are added since the last cache.
+	// RefreshVersions must return a non-null error if the versions can't be fetched or cached.
+	RefreshVersions() ([]*ExtendedVersion, []*ExtendedVersion, error)
+
+	// GetInstalledVersions returns back a slice of the installed versions, without fetching the available versions.
+	// GetInstalledVersions must return a non-null error if the installed versions can't be read.
+	GetInstalledVersions() ([]*ExtendedVersion, error)
+
+	// GetVersionDirectory returns the directory of the given installed version, which is the GOROOT of the version.
+	GetVersionDirectory(ev *ExtendedVersion) string
+
+	// ResolveMatrix returns the versions that are described from the given queries, where a query can describe multiple versions.
+	// ResolveMatrix must return a non-null error if any of the queries can't be resolved.
+	ResolveMatrix(evs []*ExtendedVersion, queries []string, includePrerelease bool) ([]*ExtendedVersion, error)
+
+	// FindBisectVersions returns the releases from the good version to the bad version, sorted from the oldest to the newest.
+	// FindBisectVersions must return a non-null error if any of the versions can't be resolved or if the good version is not older.
+	FindBisectVersions(evs []*ExtendedVersion, good string, bad string, includePrerelease bool) ([]*ExtendedVersion, error)
+
+	// GetPrunePolicy returns the stored retention policy of `gvs prune`, or DefaultPrunePolicy if there is none.
+	// GetPrunePolicy must return a non-null error if the policy can't be read.
+	GetPrunePolicy() (PrunePolicy, error)
+
+	// UpdateVersionUsage stores the current time as the time the given installed version was last used.
+	// UpdateVersionUsage must return a non-null error if the time can't be stored.
+	UpdateVersionUsage(ev *ExtendedVersion) error
+
+	// SetPrunePolicy stores the given retention policy of `gvs prune`, with the default rules if it has none, and returns it back.
+	// SetPrunePolicy must return a non-null error if the policy can't be stored.
+	SetPrunePolicy(policy PrunePolicy) (PrunePolicy, error)
+
+	// FindPrunableVersions returns the given installed versions that are not kept from the given policy.
+	// FindPrunableVersions must return a non-null error if the versions that are kept can't be found.
+	FindPrunableVersions(evs []*ExtendedVersion, policy PrunePolicy) ([]*ExtendedVersion, error)
+
+	// FindUpgradeVersion returns the newest stable patch of the release line of the current version, or the newest stable
+	// version if minor is true. FindUpgradeVersion must return nil if the current version is already the newest one,
+	// and a non-null error if there is no current version or no stable version is found.
+	FindUpgradeVersion(evs []*ExtendedVersion, minor bool) (*ExtendedVersion, error)
+
+	// GetVersionSize returns the disk size in bytes of the given installed version.
+	// GetVersionSize must return a non-null error if the directory of the version can't be read.
+	GetVersionSize(ev *ExtendedVersion) (int64, error)
+
+	// DiffAPI returns the features of the standard library API that are added from the old to the new installed version, grouped by package.
+	// DiffAPI must return a non-null error if the API of any of the versions can't be read.
+	DiffAPI(oldVersion *ExtendedVersion, newVersion *ExtendedVersion) ([]PackageAPI, error)
+
+	// EnableShims replaces the symlinks of the binaries with shims that run the given gvs executable.
+	// EnableShims must return a non-null error if the shims can't be created.
+	EnableShims(executable string, autoInstall bool) error
+
+	// DisableShims removes the shims and creates again the symlinks for the currently used version.
+	// DisableShims must return a non-null error if the shims can't be removed or the symlinks can't be created.
+	DisableShims() error
+
+	// AreShimsEnabled returns if the shims are used instead of the symlinks.
+	AreShimsEnabled() bool
+
 	// DeleteUnusedVersions deletes all the unused versions.
 	// The input should contain the versions that the method will iterate to find
 	// and delete the unused versions.
@@ -38,6 +98,14 @@ type Versioner interface {
 	// Install must return a non-null error if the install was successful.
 	Install(ev *ExtendedVersion, os string, arch string) error
 
+	// Download downloads the given version for the OS and the architecture type, without switching to it.
+	// Download must return a non-null error if the download was not successful.
+	Download(ev *ExtendedVersion, os string, arch string) error
+
+	// Activate switches to the given version, which should be already downloaded.
+	// Activate must return a non-null error if the version is not downloaded, or the switch was not successful.
+	Activate(ev *ExtendedVersion) error
+
 	// GetPromptVersions returns a filtered list of versions based on if the version is stable or not.
 	// GetPromptVersions must return a slice of *ExtendedVersion.
 	GetPromptVersions(evs []*ExtendedVersion, showAllVersions bool) []*ExtendedVersion
@@ -49,8 +117,74 @@ type Versioner interface {
 	// FindVersionBasedOnSemverName returns the version that is described in the semver.
 	// In case one of minor or patch version number is missing from the semver,
 	// FindVersionBasedOnSemverName should return the latest value of them.
+	// The betas and the release candidates should be skipped, unless includePrerelease is true
+	// or the semver is a pre-release version.
 	// If the version is not found, FindVersionBasedOnSemverName should return nil.
-	FindVersionBasedOnSemverName(evs []*ExtendedVersion, version *Semver) *ExtendedVersion
+	FindVersionBasedOnSemverName(evs []*ExtendedVersion, version *Semver, includePrerelease bool) *ExtendedVersion
+
+	// FindVersionBasedOnConstraint returns the newest version that satisfies the constraint.
+	// The betas and the release candidates should be skipped, unless includePrerelease is true
+	// or the constraint contains a pre-release version.
+	// If no version satisfies the constraint, FindVersionBasedOnConstraint should return nil.
+	FindVersionBasedOnConstraint(evs []*ExtendedVersion, constraint *Constraint, includePrerelease bool) *ExtendedVersion
+
+	// FindVersionBasedOnKeyword returns the version that is described from the keyword (e.g. `latest`, `oldstable`, `previous`).
+	// FindVersionBasedOnKeyword must return a non-null error if the keyword is not supported, or if no version is found.
+	FindVersionBasedOnKeyword(evs []*ExtendedVersion, keyword string) (*ExtendedVersion, error)
+
+	// SetAlias stores the given alias name for the version.
+	// SetAlias must return a non-null error if the name or the version are not valid, or if the alias can't be stored.
+	SetAlias(name string, version string) error
+
+	// DeleteAlias deletes the alias with the given name.
+	// DeleteAlias must return a non-null error if the alias does not exist, or if the alias can't be deleted.
+	DeleteAlias(name string) error
+
+	// GetAliases returns the user-defined aliases, sorted by name.
+	GetAliases() ([]Alias, error)
+
+	// GetAliasesForVersion returns the names of the aliases that are resolved to the given version from the given versions.
+	GetAliasesForVersion(evs []*ExtendedVersion, ev *ExtendedVersion) ([]string, error)
+
+	// GetModVersion returns the version of the go.work or the go.mod file that applies to the current directory.
+	// GetModVersion must return a non-null error if no file is found, or if the versions of the file are not valid.
+	GetModVersion(latestPatch bool) (*VersionSource, error)
+
+	// FindProjectVersion returns the version that applies to the current directory, along with where it was found.
+	// FindProjectVersion must return a non-null error if no version is found.
+	FindProjectVersion(latestPatch bool) (*VersionSource, error)
+
+	// GetVersionSources returns all the versions that apply to the current directory, in the order they are looked up.
+	// GetVersionSources must return a non-null error if any of the files can't be read.
+	GetVersionSources(latestPatch bool) ([]*VersionSource, error)
+
+	// ExplainVersion returns the resolution chain of the version that applies to the current directory.
+	// ExplainVersion must return a non-null error if no version applies to the current directory.
+	ExplainVersion(evs []*ExtendedVersion, query string, latestPatch bool, includePrerelease bool) ([]*ResolutionStep, error)
+
+	// GetGlobalVersion returns the global default version, or nil if there is no global default version.
+	// GetGlobalVersion must return a non-null error if the global default version can't be read.
+	GetGlobalVersion() (*VersionSource, error)
+
+	// SetLocalVersion writes the given version to the version file of the current directory and returns the path of the file.
+	// SetLocalVersion must return a non-null error if the version is not valid, or if the file can't be written.
+	SetLocalVersion(query string) (string, error)
+
+	// SetGlobalVersion writes the given version as the global default version and returns the path of the file.
+	// SetGlobalVersion must return a non-null error if the version is not valid, or if the file can't be written.
+	SetGlobalVersion(query string) (string, error)
+
+	// Resolve returns the version that is described from the query, which can be either an alias, a version, a keyword or a constraint expression.
+	// Resolve must return a non-null error if the query is not valid, or if no version is found.
+	Resolve(evs []*ExtendedVersion, query string, includePrerelease bool) (*ExtendedVersion, error)
+
+	// Uninstall deletes the given installed version, where force allows the currently used version to be deleted.
+	// Uninstall must return a non-null error if the version is not installed, is currently used without force, or if the deletion fails.
+	Uninstall(ev *ExtendedVersion, force bool) error
+
+	// GetCurrentVersion returns the currently used version without the `go` prefix.
+	// GetCurrentVersion must return an empty string if there is no used version.
+	GetCurrentVersion() string
 }
 
 // Version is the struct that implements the Versioner interface.
@@ -77,6 +211,9 @@ type ExtendedVersion struct {
 	// AlreadyInstalled indicates if the version is already installed.
 	AlreadyInstalled bool
 
+	// SupportStatus indicates if the version is supported by the Go team (see FindSupportedVersions).
+	SupportStatus SupportStatus
+
 	api_client.VersionInfo
 }
 
@@ -96,10 +233,12 @@ func (ev *ExtendedVersion) addExtras(helper files.FileHelpers) {
 // GetPromptName returns the version name as it will be rendered on the dropdown prompt.
 //
 // If the `showStable` is set to true, it will also include if the version is stable or not.
+// The versions that are not supported by the Go team anymore are marked as unsupported.
 // Examples:
 //   - 1.21.3 (stable) - current version
 //   - 1.21.0 (stable) - already downloaded
 //   - 1.21rc4 (unstable)
+//   - 1.19.13 (stable) - unsupported
 //
 // If the `showStable` is set to false, the text in the paragraphs will be omitted.
 func (ev ExtendedVersion) GetPromptName(showStable bool) string {
@@ -114,6 +253,10 @@ func (ev ExtendedVersion) GetPromptName(showStable bool) string {
 		message = fmt.Sprintf("%s (%s)", message, stable)
 	}
 
+	if ev.SupportStatus == Unsupported {
+		message += " - unsupported"
+	}
+
 	if ev.AlreadyInstalled && !ev.UsedVersion {
 		message += " - already downloaded"
 	}
@@ -134,6 +277,40 @@ func (ev ExtendedVersion) getCleanVersionName() string {
 	return strings.TrimPrefix(ev.Version, "go")
 }
 
+// getSemver parses the version name into a semantic version structure.
+//
+// If the version name does not follow the Go release version grammar, getSemver returns an error.
+func (ev ExtendedVersion) getSemver() (*Semver, error) {
+	semver := &Semver{}
+	if err := ParseSemver(ev.Version, semver); err != nil {
+		return nil, err
+	}
+
+	return semver, nil
+}
+
+// SortVersions sorts the given versions in place, from the newest to the oldest version,
+// based on the ordering that Compare defines.
+// Versions with a name that can't be parsed are moved to the end, keeping their original order.
+func SortVersions(evs []*ExtendedVersion) {
+	semvers := make(map[*ExtendedVersion]*Semver, len(evs))
+	for _, ev := range evs {
+		if semver, err := ev.getSemver(); err == nil {
+			semvers[ev] = semver
+		}
+	}
+
+	sort.SliceStable(evs, func(i, j int) bool {
+		a, b := semvers[evs[i]], semvers[evs[j]]
+
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+
+		return a.Compare(*b) > 0
+	})
+}
+
 // FilterAlreadyDownloadedVersions returns the version names that are already installed.
 func (v Version) FilterAlreadyDownloadedVersions(evs []*ExtendedVersion) []string {
 	installedVersions := make([]string, 0, len(evs))
@@ -156,6 +333,9 @@ func (v Version) FilterAlreadyDownloadedVersions(evs []*ExtendedVersion) []strin
 //
 // Finally, for each available version, GetVersions includes the "extra" attributes in each of the ExtendedVersion
 // types that indicates if a version is already installed and/or currently used.
+//
+// The versions are sorted from the newest to the oldest one, instead of trusting the order of the response,
+// and their support status is computed from the two latest release lines.
 func (v Version) GetVersions(forceFetchVersions bool) ([]*ExtendedVersion, error) {
 	var responseVersions []api_client.VersionInfo
 
@@ -189,9 +369,51 @@ func (v Version) GetVersions(forceFetchVersions bool) ([]*ExtendedVersion, error
 		versions = append(versions, version)
 	}
 
+	SortVersions(versions)
+	setSupportStatus(versions)
+
 	return versions, nil
 }
 
+// GetInstalledVersions returns back a slice of the installed versions, sorted from the newest to the oldest.
+//
+// The versions are read from the installed directories, without fetching the available versions or reading
+// the cached ones, so it can be used when the versions need to be resolved fast (e.g. from the shell hook).
+// Since the checksums are not known, the returned versions can't be installed again.
+//
+// If the installed directories can't be read, GetInstalledVersions returns back an error.
+func (v Version) GetInstalledVersions() ([]*ExtendedVersion, error) {
+	names, err := v.fileHelpers.GetInstalledVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*ExtendedVersion, 0, len(names))
+	for _, name := range names {
+		version := &ExtendedVersion{VersionInfo: api_client.VersionInfo{Version: name, Files: []api_client.FileInformation{}}}
+
+		semver, err := version.getSemver()
+		if err != nil {
+			// skip the directories that are not Go versions
+			continue
+		}
+
+		version.IsStable = !semver.IsPrerelease()
+		version.addExtras(v.fileHelpers)
+
+		versions = append(versions, version)
+	}
+
+	SortVersions(versions)
+
+	return versions, nil
+}
+
+// GetVersionDirectory returns the directory of the given installed version, which is the GOROOT of the version.
+func (v Version) GetVersionDirectory(ev *ExtendedVersion) string {
+	return v.fileHelpers.GetVersionDirectory(ev.Version)
+}
+
 // DeleteUnusedVersions deletes all the unused versions.
 // If there is no any unused version, DeleteUnusedVersions will return -1 as the count and an error
 // of the type *NoInstalledVersionsError.
@@ -224,20 +446,37 @@ func (v Version) DeleteUnusedVersions(evs []*ExtendedVersion) (int, error) {
 }
 
 // GetLatestVersion returns the latest stable version.
+// The versions are compared with each other, so the order of the given versions does not matter.
 //
 // GetLatestVersion returns the the index of the found version, or -1 if not found.
 func (v Version) GetLatestVersion(evs []*ExtendedVersion) int {
+	latestIndex := -1
+	var latest *Semver
+
 	for i, vi := range evs {
-		if vi.IsStable {
-			return i
+		if !vi.IsStable {
+			continue
+		}
+
+		semver, err := vi.getSemver()
+		if err != nil {
+			continue
+		}
+
+		if latest == nil || semver.Compare(*latest) > 0 {
+			latestIndex = i
+			latest = semver
 		}
 	}
 
-	return -1
+	return latestIndex
 }
 
 // Install installs the given version for the OS and the architecture type.
 //
+// Install first downloads the version (if it's not already downloaded) and then activates it,
+// which is the same as calling Download and Activate.
+//
 // If for a new version (which has not been downloaded already in the past):
 //
 //		if the archive file is not found for the OS and the architecture type, then an error of
@@ -249,37 +488,120 @@ func (v Version) GetLatestVersion(evs []*ExtendedVersion) int {
 // Otherwise, any other error that might occur during the install of an existing or a new version
 // will be returned back.
 func (v Version) Install(ev *ExtendedVersion, os string, arch string) error {
-	if ev.AlreadyInstalled {
-		err := v.installer.ExistingVersion(ev.Version)
-		if err != nil {
+	if !ev.AlreadyInstalled {
+		if err := v.download(ev, os, arch); err != nil {
 			return err
 		}
-	} else {
-		var fileName string
-		var checksum string
+	}
 
-		for _, file := range ev.Files {
-			if file.Architecture == arch && file.OS == os && file.Kind == "archive" {
-				fileName = file.Filename
-				checksum = file.Checksum
-			}
-		}
+	if err := v.activate(ev); err != nil {
+		return err
+	}
 
-		if fileName == "" {
-			return &errors.InstallerNotFoundError{OS: os, Arch: arch}
-		}
+	v.log.PrintMessage("%s version is installed!\n", ev.getCleanVersionName())
 
-		if checksum == "" {
-			return &errors.ChecksumNotFoundError{OS: os, Arch: arch}
-		}
+	return nil
+}
 
-		err := v.installer.NewVersion(context.Background(), fileName, checksum, ev.Version)
-		if err != nil {
-			return err
+// getArchive returns the file name and the checksum of the archive file for the OS and the architecture type.
+//
+// If the archive file is not found for the OS and the architecture type, then an error of
+// the type *InstallerNotFoundError is returned.
+//
+// If checksum is not found for the OS and the architecture type, then an error of
+// the type *ChecksumNotFoundError is returned.
+func getArchive(ev *ExtendedVersion, os string, arch string) (string, string, error) {
+	var fileName string
+	var checksum string
+
+	for _, file := range ev.Files {
+		if file.Architecture == arch && file.OS == os && file.Kind == "archive" {
+			fileName = file.Filename
+			checksum = file.Checksum
 		}
 	}
 
-	v.log.PrintMessage("%s version is installed!\n", ev.getCleanVersionName())
+	if fileName == "" {
+		return "", "", &errors.InstallerNotFoundError{OS: os, Arch: arch}
+	}
+
+	if checksum == "" {
+		return "", "", &errors.ChecksumNotFoundError{OS: os, Arch: arch}
+	}
+
+	return fileName, checksum, nil
+}
+
+// Download downloads the given version for the OS and the architecture type, without switching to it.
+// The symbolic links and the file that holds the current version are not touched, so Download can be
+// used to prefetch versions that will be activated later.
+//
+// If the version is already downloaded, Download does nothing.
+//
+// The errors are the same as the ones that Install returns for new versions.
+func (v Version) Download(ev *ExtendedVersion, os string, arch string) error {
+	if ev.AlreadyInstalled {
+		v.log.PrintMessage("%s version is already downloaded.\n", ev.getCleanVersionName())
+		return nil
+	}
+
+	if err := v.download(ev, os, arch); err != nil {
+		return err
+	}
+
+	v.log.PrintMessage("%s version is downloaded!\n", ev.getCleanVersionName())
+
+	return nil
+}
+
+// download downloads, verifies and extracts the archive of the version for the OS and the architecture type,
+// without creating the symbolic links.
+//
+// If for any reason if fails, download will return back the error.
+func (v Version) download(ev *ExtendedVersion, os string, arch string) error {
+	fileName, checksum, err := getArchive(ev, os, arch)
+	if err != nil {
+		return err
+	}
+
+	if err := v.installer.Download(context.Background(), fileName, checksum, ev.Version); err != nil {
+		return err
+	}
+
+	ev.AlreadyInstalled = true
+
+	return nil
+}
+
+// Activate switches to the given version, by creating the symbolic links and updating the
+// file that holds the current version.
+//
+// If the version is not downloaded, Activate will return an error of the type *VersionNotInstalledError.
+// Otherwise, any error that might occur during the switch will be returned back.
+func (v Version) Activate(ev *ExtendedVersion) error {
+	if !ev.AlreadyInstalled {
+		return &errors.VersionNotInstalledError{Version: ev.getCleanVersionName()}
+	}
+
+	if err := v.activate(ev); err != nil {
+		return err
+	}
+
+	v.log.PrintMessage("%s version is activated!\n", ev.getCleanVersionName())
+
+	return nil
+}
+
+// activate creates the symbolic links of an already downloaded version and updates the file
+// that holds the current version.
+//
+// If for any reason if fails, activate will return back the error.
+func (v Version) activate(ev *ExtendedVersion) error {
+	if err := v.installer.ExistingVersion(ev.Version); err != nil {
+		return err
+	}
+
+	ev.UsedVersion = true
 
 	return nil
 }
@@ -296,22 +618,222 @@ func (v Version) GetPromptVersions(evs []*ExtendedVersion, showAllVersions bool)
 }
 
 // FindVersionBasedOnSemverName returns the version that is described in the semver.
-// It compares the stringified semver version as a prefix for each one of the versions.
-// FindVersionBasedOnSemverName returns back the first occurrence of that version, which ensures the
+//
+// The versions are matched component by component (see Semver.Includes), so `1.2` matches only
+// the 1.2 versions (and not `1.21.3`), while a version with a patch or a pre-release number matches only that exact version.
+// FindVersionBasedOnSemverName returns back the newest of the matching versions, which ensures the
 // latest version will be returned when minor or patch versions are not assigned to the semver.
+//
+// The betas and the release candidates are skipped, unless includePrerelease is true or the semver
+// is a pre-release version (e.g. `1.21rc2`).
 // If the version is not found, FindVersionBasedOnSemverName return back nil.
-func (v Version) FindVersionBasedOnSemverName(evs []*ExtendedVersion, version *Semver) *ExtendedVersion {
-	expectedVersion := version.GetVersion()
+func (v Version) FindVersionBasedOnSemverName(evs []*ExtendedVersion, version *Semver, includePrerelease bool) *ExtendedVersion {
+	includePrerelease = includePrerelease || version.IsPrerelease()
+
+	var found *ExtendedVersion
+	var foundSemver *Semver
+
+	for _, ev := range evs {
+		semver, err := ev.getSemver()
+		if err != nil {
+			continue
+		}
+
+		if semver.IsPrerelease() && !includePrerelease {
+			continue
+		}
+
+		if !version.Includes(*semver) {
+			continue
+		}
+
+		if found == nil || semver.Compare(*foundSemver) > 0 {
+			found = ev
+			foundSemver = semver
+		}
+	}
+
+	return found
+}
+
+// FindVersionBasedOnConstraint returns the newest version that satisfies the constraint.
+//
+// The betas and the release candidates are skipped, unless includePrerelease is true or
+// the constraint contains a pre-release version (e.g. `>=1.22rc1`).
+// If no version satisfies the constraint, FindVersionBasedOnConstraint returns back nil.
+func (v Version) FindVersionBasedOnConstraint(evs []*ExtendedVersion, constraint *Constraint, includePrerelease bool) *ExtendedVersion {
+	includePrerelease = includePrerelease || constraint.HasPrerelease()
+
+	var found *ExtendedVersion
+	var foundSemver *Semver
 
 	for _, ev := range evs {
-		if strings.HasPrefix(ev.getCleanVersionName(), expectedVersion) {
-			return ev
+		semver, err := ev.getSemver()
+		if err != nil {
+			continue
+		}
+
+		if semver.IsPrerelease() && !includePrerelease {
+			continue
+		}
+
+		if !constraint.Check(*semver) {
+			continue
+		}
+
+		if found == nil || semver.Compare(*foundSemver) > 0 {
+			found = ev
+			foundSemver = semver
 		}
 	}
 
+	return found
+}
+
+// findClosestVersions returns the names of the versions that are the closest to the given version,
+// so they can be suggested when the version is not found.
+//
+// The closest versions are the newest pre-release that matches the version (if the pre-releases were skipped),
+// the newest version that is older than the version and the oldest version that is newer than the version.
+func findClosestVersions(evs []*ExtendedVersion, version *Semver, includePrerelease bool) []string {
+	var prerelease, older, newer *Semver
+
+	for _, ev := range evs {
+		semver, err := ev.getSemver()
+		if err != nil {
+			continue
+		}
+
+		if semver.IsPrerelease() && !includePrerelease {
+			if version.Includes(*semver) && (prerelease == nil || semver.Compare(*prerelease) > 0) {
+				prerelease = semver
+			}
+
+			continue
+		}
+
+		compared := semver.Compare(*version)
+		if compared < 0 && (older == nil || semver.Compare(*older) > 0) {
+			older = semver
+		}
+
+		if compared > 0 && (newer == nil || semver.Compare(*newer) < 0) {
+			newer = semver
+		}
+	}
+
+	candidates := []string{}
+	for _, semver := range []*Semver{prerelease, older, newer} {
+		if semver != nil {
+			candidates = append(candidates, semver.GetVersion())
+		}
+	}
+
+	return candidates
+}
+
+// Resolve returns the version that is described from the query.
+//
+// The query can be either a user-defined alias (see SetAlias), which is replaced with the version it points to,
+// a version (e.g. `1.21`, `1.21.3`), which is resolved with FindVersionBasedOnSemverName,
+// a keyword (e.g. `latest`, `oldstable`), which is resolved with FindVersionBasedOnKeyword,
+// or a constraint expression (e.g. `>=1.21.4 <1.23`), which is resolved with FindVersionBasedOnConstraint.
+//
+// If the query can't be parsed or no version is found, Resolve returns back an error.
+func (v Version) Resolve(evs []*ExtendedVersion, query string, includePrerelease bool) (*ExtendedVersion, error) {
+	aliases, err := v.fileHelpers.GetAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	if aliasVersion, ok := aliases[query]; ok {
+		v.log.Info("alias %q points to %q\n", query, aliasVersion)
+		query = aliasVersion
+	}
+
+	return v.resolveQuery(evs, query, includePrerelease)
+}
+
+// resolveQuery returns the version that is described from the query, without looking up the aliases.
+func (v Version) resolveQuery(evs []*ExtendedVersion, query string, includePrerelease bool) (*ExtendedVersion, error) {
+	if IsKeyword(query) {
+		return v.FindVersionBasedOnKeyword(evs, query)
+	}
+
+	if IsConstraint(query) {
+		constraint := &Constraint{}
+		if err := ParseConstraint(query, constraint); err != nil {
+			return nil, err
+		}
+
+		selectedVersion := v.FindVersionBasedOnConstraint(evs, constraint, includePrerelease)
+		if selectedVersion == nil {
+			return nil, fmt.Errorf("no version satisfies %q", constraint.String())
+		}
+
+		return selectedVersion, nil
+	}
+
+	semver := &Semver{}
+	if err := ParseSemver(query, semver); err != nil {
+		return nil, err
+	}
+
+	selectedVersion := v.FindVersionBasedOnSemverName(evs, semver, includePrerelease)
+	if selectedVersion == nil {
+		return nil, &errors.VersionNotFoundError{Version: semver.GetVersion(), Candidates: findClosestVersions(evs, semver, includePrerelease)}
+	}
+
+	return selectedVersion, nil
+}
+
+// Uninstall deletes the given installed version. The currently used version is deleted only if force is true,
+// and then its symlinks are removed as well, so there is no current version until another version is used.
+//
+// If the version is not installed, Uninstall will return an error of the type *VersionNotInstalledError.
+// If the version is currently used and force is false, Uninstall will return an error of the type *VersionInUseError.
+// If the deletion fails, or the symlinks of the current version can't be removed,
+// Uninstall will return an error of the type *DeleteVersionError.
+func (v Version) Uninstall(ev *ExtendedVersion, force bool) error {
+	if !ev.AlreadyInstalled {
+		return &errors.VersionNotInstalledError{Version: ev.getCleanVersionName()}
+	}
+
+	if ev.UsedVersion && !force {
+		return &errors.VersionInUseError{Version: ev.getCleanVersionName()}
+	}
+
+	v.log.PrintMessage("Deleting %s.\n", ev.Version)
+	if err := v.fileHelpers.DeleteDirectory(ev.Version); err != nil {
+		return &errors.DeleteVersionError{Err: err, Version: ev.Version}
+	}
+
+	ev.AlreadyInstalled = false
+	v.log.PrintMessage("%s is deleted.\n", ev.Version)
+
+	if ev.UsedVersion {
+		if err := v.fileHelpers.RemoveExecutableSymlinks(ev.Version); err != nil {
+			return &errors.DeleteVersionError{Err: err, Version: ev.Version}
+		}
+
+		if err := v.fileHelpers.ClearRecentVersion(); err != nil {
+			return &errors.DeleteVersionError{Err: err, Version: ev.Version}
+		}
+
+		ev.UsedVersion = false
+		v.log.PrintMessage("%s was the current version, run 'gvs use' to select another version.\n", ev.getCleanVersionName())
+	}
+
 	return nil
 }
 
+// GetCurrentVersion returns the currently used version without the `go` prefix (e.g. `1.21.3`).
+//
+// If there is no used version, GetCurrentVersion returns an empty string.
+func (v Version) GetCurrentVersion() string {
+	return strings.TrimPrefix(v.fileHelpers.GetRecentVersion(), "go")
+}
+
 // New returns a Version instance that implements the Versioner interface.
 // Each call to New returns a distinct Version instance even if the parameters are identical.
 func New(fileHelpers files.FileHelpers, clientAPI api_client.GoClientAPI, installer install.Installer, logger *logger.Log) Version {
@@ -1,6 +1,7 @@
 package version_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -252,6 +253,69 @@ func TestGetVersionsAddCorrectExtras(t *testing.T) {
 	}
 }
 
+func TestGetInstalledVersions(t *testing.T) {
+	fileHelpers := &testutils.FakeFilesHelper{
+		RecentVersion:             "go1.21.0",
+		InstalledVersions:         []string{"go1.19.0", "go", "go1.22rc1", "go1.21.0"},
+		AlreadyDownloadedVersions: []string{"go1.19.0", "go1.22rc1", "go1.21.0"},
+	}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	versions, err := versioner.GetInstalledVersions()
+
+	if err != nil {
+		t.Errorf("error should be nil, instead got %q", err.Error())
+		return
+	}
+
+	expectedNames := []string{"go1.22rc1", "go1.21.0", "go1.19.0"}
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		names = append(names, v.Version)
+
+		if !v.AlreadyInstalled {
+			t.Errorf("%s should be already installed", v.Version)
+		}
+
+		if v.UsedVersion != (v.Version == "go1.21.0") {
+			t.Errorf("%s used version should be %t", v.Version, !v.UsedVersion)
+		}
+
+		if v.IsStable != (v.Version != "go1.22rc1") {
+			t.Errorf("%s stable should be %t", v.Version, !v.IsStable)
+		}
+	}
+
+	if !cmp.Equal(names, expectedNames) {
+		t.Errorf("Wrong versions received, got=%s", cmp.Diff(names, expectedNames))
+	}
+}
+
+func TestGetInstalledVersionsError(t *testing.T) {
+	expectedError := fmt.Errorf("an error occurred while reading the directory path")
+
+	fileHelpers := &testutils.FakeFilesHelper{GetInstalledVersionsError: expectedError}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	versions, err := versioner.GetInstalledVersions()
+
+	if err == nil || err.Error() != expectedError.Error() {
+		t.Errorf("error should be %q, instead got %v", expectedError.Error(), err)
+	}
+
+	if versions != nil {
+		t.Error("versions should be nil")
+	}
+}
+
 func TestGetVersionsFromCacheError(t *testing.T) {
 	expectedError := fmt.Errorf("An error happened")
 
@@ -595,8 +659,8 @@ func TestInstallShouldInstallExistingVersionSuccess(t *testing.T) {
 		t.Errorf("ExistingVersion should have been called")
 	}
 
-	if installer.NewVersionCalled {
-		t.Errorf("NewVersion should not have been called")
+	if installer.DownloadCalled {
+		t.Errorf("Download should not have been called")
 	}
 
 	if err != nil {
@@ -823,12 +887,20 @@ func TestInstallNewVersionSuccess(t *testing.T) {
 
 	err := versioner.Install(&ev, os, arch)
 
-	if !installer.NewVersionCalled {
-		t.Errorf("NewVersionCalled should have been called")
+	if !installer.DownloadCalled {
+		t.Errorf("Download should have been called")
 	}
 
-	if installer.ExistingVersionCalled {
-		t.Errorf("ExistingVersion should not have been called")
+	if !installer.ExistingVersionCalled {
+		t.Errorf("ExistingVersion should have been called")
+	}
+
+	if installer.NewVersionCalled {
+		t.Errorf("NewVersion should not have been called")
+	}
+
+	if !ev.AlreadyInstalled || !ev.UsedVersion {
+		t.Errorf("version should be downloaded and used, instead got AlreadyInstalled=%t UsedVersion=%t", ev.AlreadyInstalled, ev.UsedVersion)
 	}
 
 	if err != nil {
@@ -912,7 +984,7 @@ func TestInstallNewVersionError(t *testing.T) {
 	fileHelpers := &testutils.FakeFilesHelper{}
 	clientAPI := testutils.FakeGoClientAPI{}
 	installer := &testutils.FakeInstaller{
-		NewVersionError: expectedError,
+		DownloadError: expectedError,
 	}
 	log := logger.New(&testutils.FakeStdout{}, nil)
 
@@ -923,6 +995,10 @@ func TestInstallNewVersionError(t *testing.T) {
 	if err.Error() != expectedError.Error() {
 		t.Errorf("error should be %q, instead got %q", expectedError.Error(), err.Error())
 	}
+
+	if installer.ExistingVersionCalled {
+		t.Errorf("ExistingVersion should not have been called")
+	}
 }
 
 func TestGetPromptVersionsStableOnly(t *testing.T) {
@@ -1133,6 +1209,34 @@ func TestExtendedVersionGetPromptNameShowStable(t *testing.T) {
 			showStable: false,
 			message:    "1.21rc2",
 		},
+		{
+			version: version.ExtendedVersion{
+				UsedVersion:      false,
+				AlreadyInstalled: true,
+				SupportStatus:    version.Unsupported,
+				VersionInfo: api_client.VersionInfo{
+					Version:  "go1.19.13",
+					IsStable: true,
+					Files:    []api_client.FileInformation{},
+				},
+			},
+			showStable: true,
+			message:    "1.19.13 (stable) - unsupported - already downloaded",
+		},
+		{
+			version: version.ExtendedVersion{
+				UsedVersion:      false,
+				AlreadyInstalled: false,
+				SupportStatus:    version.Supported,
+				VersionInfo: api_client.VersionInfo{
+					Version:  "go1.21.0",
+					IsStable: true,
+					Files:    []api_client.FileInformation{},
+				},
+			},
+			showStable: false,
+			message:    "1.21.0",
+		},
 	}
 
 	for _, param := range parameters {
@@ -1208,6 +1312,7 @@ func TestFindVersionBasedOnSemverName(t *testing.T) {
 	testCases := []struct {
 		testTitle         string
 		semver            version.Semver
+		includePrerelease bool
 		expecectedVersion *version.ExtendedVersion
 	}{
 		{
@@ -1235,6 +1340,22 @@ func TestFindVersionBasedOnSemverName(t *testing.T) {
 			semver:            version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(30)},
 			expecectedVersion: nil,
 		},
+		{
+			testTitle:         "should not match versions that only share the same prefix",
+			semver:            version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(2)},
+			expecectedVersion: nil,
+		},
+		{
+			testTitle:         "should not match a patch version that only shares the same prefix",
+			semver:            version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(20), Patch: intToUnsigned(0)},
+			expecectedVersion: nil,
+		},
+		{
+			testTitle:         "should return the newest release over the pre-releases when includePrerelease is true",
+			semver:            version.Semver{Major: intToUnsigned(1), Minor: intToUnsigned(20)},
+			includePrerelease: true,
+			expecectedVersion: versions[2],
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1246,7 +1367,7 @@ func TestFindVersionBasedOnSemverName(t *testing.T) {
 
 			versioner := version.New(fileHelpers, clientAPI, installer, log)
 
-			res := versioner.FindVersionBasedOnSemverName(versions, &tc.semver)
+			res := versioner.FindVersionBasedOnSemverName(versions, &tc.semver, tc.includePrerelease)
 
 			if !cmp.Equal(res, tc.expecectedVersion) {
 				t.Errorf("Wrong array received, got=%s", cmp.Diff(res, tc.expecectedVersion))
@@ -1254,3 +1375,569 @@ func TestFindVersionBasedOnSemverName(t *testing.T) {
 		})
 	}
 }
+
+func getConstraintVersions() []*version.ExtendedVersion {
+	names := []string{"go1.23rc1", "go1.22.1", "go1.22.0", "go1.21.5", "go1.21.4", "go1.21.3", "go1.20.12"}
+
+	versions := make([]*version.ExtendedVersion, 0, len(names))
+	for _, name := range names {
+		versions = append(versions, &version.ExtendedVersion{
+			VersionInfo: api_client.VersionInfo{
+				Version:  name,
+				IsStable: name != "go1.23rc1",
+				Files:    []api_client.FileInformation{},
+			},
+		})
+	}
+
+	return versions
+}
+
+func TestFindVersionBasedOnConstraint(t *testing.T) {
+	versions := getConstraintVersions()
+
+	testCases := []struct {
+		testTitle         string
+		expression        string
+		includePrerelease bool
+		expecectedVersion *version.ExtendedVersion
+	}{
+		{
+			testTitle:         "should return the newest version that satisfies the constraint",
+			expression:        ">=1.21.4 <1.22",
+			expecectedVersion: versions[3],
+		},
+		{
+			testTitle:         "should return the newest version of the minor version",
+			expression:        "~1.21.3",
+			expecectedVersion: versions[3],
+		},
+		{
+			testTitle:         "should skip the excluded versions",
+			expression:        "^1.22, !=1.22.1",
+			expecectedVersion: versions[2],
+		},
+		{
+			testTitle:         "should return the newest version of any alternative",
+			expression:        "1.20 || ~1.21.4",
+			expecectedVersion: versions[3],
+		},
+		{
+			testTitle:         "should skip the pre-releases by default",
+			expression:        ">=1.22",
+			expecectedVersion: versions[1],
+		},
+		{
+			testTitle:         "should return a pre-release when includePrerelease is true",
+			expression:        ">=1.22",
+			includePrerelease: true,
+			expecectedVersion: versions[0],
+		},
+		{
+			testTitle:         "should return a pre-release when the constraint contains a pre-release",
+			expression:        ">=1.23rc1",
+			expecectedVersion: versions[0],
+		},
+		{
+			testTitle:         "should return nil when no version satisfies the constraint",
+			expression:        ">1.23",
+			expecectedVersion: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			constraint := &version.Constraint{}
+			if err := version.ParseConstraint(tc.expression, constraint); err != nil {
+				t.Fatalf("error should be nil, instead got %q", err.Error())
+			}
+
+			res := versioner.FindVersionBasedOnConstraint(versions, constraint, tc.includePrerelease)
+
+			if !cmp.Equal(res, tc.expecectedVersion) {
+				t.Errorf("Wrong version received, got=%s", cmp.Diff(res, tc.expecectedVersion))
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	versions := getConstraintVersions()
+
+	testCases := []struct {
+		testTitle         string
+		query             string
+		includePrerelease bool
+		expecectedVersion *version.ExtendedVersion
+		expectedError     error
+	}{
+		{
+			testTitle:         "should resolve a version",
+			query:             "1.21",
+			expecectedVersion: versions[3],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should resolve a keyword",
+			query:             "oldstable",
+			expecectedVersion: versions[3],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should resolve a constraint",
+			query:             ">=1.21.4 <1.22",
+			expecectedVersion: versions[3],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should return an error when the version is not valid",
+			query:             "1.21.x",
+			expecectedVersion: nil,
+			expectedError:     errors.New("invalid Go version"),
+		},
+		{
+			testTitle:         "should return an error with the closest version when the version is not found",
+			query:             "1.30",
+			expecectedVersion: nil,
+			expectedError:     errors.New("1.30 is not a valid version, the closest versions are: 1.22.1"),
+		},
+		{
+			testTitle:         "should return an error with the closest versions when the version is between other versions",
+			query:             "1.21.6",
+			expecectedVersion: nil,
+			expectedError:     errors.New("1.21.6 is not a valid version, the closest versions are: 1.21.5, 1.22.0"),
+		},
+		{
+			testTitle:         "should return an error with the skipped pre-release when only a pre-release matches the version",
+			query:             "1.23",
+			expecectedVersion: nil,
+			expectedError:     errors.New("1.23 is not a valid version, the closest versions are: 1.23rc1, 1.22.1"),
+		},
+		{
+			testTitle:         "should return an error with only the newer version when there are no older versions",
+			query:             "1.1",
+			expecectedVersion: nil,
+			expectedError:     errors.New("1.1 is not a valid version, the closest versions are: 1.20.12"),
+		},
+		{
+			testTitle:         "should resolve a pre-release when includePrerelease is true",
+			query:             "1.23",
+			includePrerelease: true,
+			expecectedVersion: versions[0],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should resolve a pre-release when the version is a pre-release",
+			query:             "1.23rc1",
+			expecectedVersion: versions[0],
+			expectedError:     nil,
+		},
+		{
+			testTitle:         "should return an error when the constraint is not valid",
+			query:             ">=1.21.x",
+			expecectedVersion: nil,
+			expectedError:     errors.New(`invalid version "1.21.x" in constraint ">=1.21.x"`),
+		},
+		{
+			testTitle:         "should return an error when no version satisfies the constraint",
+			query:             ">1.23",
+			expecectedVersion: nil,
+			expectedError:     errors.New(`no version satisfies ">1.23"`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			res, err := versioner.Resolve(versions, tc.query, tc.includePrerelease)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if !cmp.Equal(res, tc.expecectedVersion) {
+				t.Errorf("Wrong version received, got=%s", cmp.Diff(res, tc.expecectedVersion))
+			}
+		})
+	}
+}
+
+func TestUninstall(t *testing.T) {
+	testCases := []struct {
+		testTitle        string
+		version          version.ExtendedVersion
+		force            bool
+		deleteError      error
+		symlinksError    error
+		expectedError    error
+		expectedMessages []string
+	}{
+		{
+			testTitle: "should return an error when the version is not installed",
+			version: version.ExtendedVersion{
+				VersionInfo: api_client.VersionInfo{Version: "go1.21.0", IsStable: true},
+			},
+			expectedError: fmt.Errorf("1.21.0 is not installed"),
+		},
+		{
+			testTitle: "should return an error when the version is the current version",
+			version: version.ExtendedVersion{
+				UsedVersion:      true,
+				AlreadyInstalled: true,
+				VersionInfo:      api_client.VersionInfo{Version: "go1.21.0", IsStable: true},
+			},
+			expectedError: fmt.Errorf("1.21.0 is the current version"),
+		},
+		{
+			testTitle: "should delete the current version when force is true",
+			version: version.ExtendedVersion{
+				UsedVersion:      true,
+				AlreadyInstalled: true,
+				VersionInfo:      api_client.VersionInfo{Version: "go1.21.0", IsStable: true},
+			},
+			force: true,
+			expectedMessages: []string{
+				"Deleting go1.21.0.\n",
+				"go1.21.0 is deleted.\n",
+				"1.21.0 was the current version, run 'gvs use' to select another version.\n",
+			},
+		},
+		{
+			testTitle: "should return an error when the symlinks of the current version can't be removed",
+			version: version.ExtendedVersion{
+				UsedVersion:      true,
+				AlreadyInstalled: true,
+				VersionInfo:      api_client.VersionInfo{Version: "go1.21.0", IsStable: true},
+			},
+			force:            true,
+			symlinksError:    fmt.Errorf("some error"),
+			expectedError:    fmt.Errorf("an error occurred while deleting \"go1.21.0\": \"some error\""),
+			expectedMessages: []string{"Deleting go1.21.0.\n", "go1.21.0 is deleted.\n"},
+		},
+		{
+			testTitle: "should return an error when the deletion fails",
+			version: version.ExtendedVersion{
+				AlreadyInstalled: true,
+				VersionInfo:      api_client.VersionInfo{Version: "bad_version", IsStable: true},
+			},
+			deleteError:      fmt.Errorf("some error"),
+			expectedError:    fmt.Errorf("an error occurred while deleting \"bad_version\": \"some error\""),
+			expectedMessages: []string{"Deleting bad_version.\n"},
+		},
+		{
+			testTitle: "should delete the version",
+			version: version.ExtendedVersion{
+				AlreadyInstalled: true,
+				VersionInfo:      api_client.VersionInfo{Version: "go1.20.0", IsStable: true},
+			},
+			expectedMessages: []string{"Deleting go1.20.0.\n", "go1.20.0 is deleted.\n"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			printer := &testutils.FakeStdout{}
+			fileHelpers := &testutils.FakeFilesHelper{
+				DeleteDirectoryError: tc.deleteError,
+				RemoveSymlinksError:  tc.symlinksError,
+				RecentVersion:        "go1.21.0",
+			}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(printer, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			usedVersion := tc.version.UsedVersion
+			err := versioner.Uninstall(&tc.version, tc.force)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if tc.expectedError == nil && tc.version.AlreadyInstalled {
+				t.Errorf("%s should not be marked as installed", tc.version.Version)
+			}
+
+			if tc.expectedError == nil && tc.version.UsedVersion {
+				t.Errorf("%s should not be marked as used", tc.version.Version)
+			}
+
+			if tc.expectedError == nil && fileHelpers.RemoveSymlinksCalled != usedVersion {
+				t.Errorf("the symlinks should be removed only for the current version, instead got %v", fileHelpers.RemoveSymlinksCalled)
+			}
+
+			if tc.expectedError == nil && usedVersion && fileHelpers.RecentVersion != "" {
+				t.Errorf("the current version should be cleared, instead got %q", fileHelpers.RecentVersion)
+			}
+
+			if !cmp.Equal(printer.GetPrintMessages(), tc.expectedMessages) {
+				t.Errorf("Wrong logs received, got=%s", cmp.Diff(tc.expectedMessages, printer.GetPrintMessages()))
+			}
+		})
+	}
+}
+
+func TestGetCurrentVersion(t *testing.T) {
+	testCases := []struct {
+		testTitle       string
+		recentVersion   string
+		expectedVersion string
+	}{
+		{
+			testTitle:       "should return an empty string when there is no used version",
+			recentVersion:   "",
+			expectedVersion: "",
+		},
+		{
+			testTitle:       "should return the used version without the go prefix",
+			recentVersion:   "go1.21.3",
+			expectedVersion: "1.21.3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{RecentVersion: tc.recentVersion}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			res := versioner.GetCurrentVersion()
+
+			if res != tc.expectedVersion {
+				t.Errorf("version should be %q, instead got %q", tc.expectedVersion, res)
+			}
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+	archive := api_client.FileInformation{
+		Filename:     "go1.21.0.darwin-arm64.tar.gz",
+		OS:           "darwin",
+		Architecture: "arm64",
+		Version:      "go1.21.0",
+		Checksum:     "ccd94d7a7b4f3d3e038d0ec608334c827ee8c67fc4c80a6d6037c8f5938aeb78",
+		Size:         64768082,
+		Kind:         "archive",
+	}
+
+	testCases := []struct {
+		testTitle              string
+		alreadyInstalled       bool
+		files                  []api_client.FileInformation
+		downloadError          error
+		expectedError          error
+		expectedDownloadCalled bool
+		expectedMessages       []string
+	}{
+		{
+			testTitle:              "should not download the version when it is already downloaded",
+			alreadyInstalled:       true,
+			files:                  []api_client.FileInformation{archive},
+			expectedDownloadCalled: false,
+			expectedMessages:       []string{"1.21.0 version is already downloaded.\n"},
+		},
+		{
+			testTitle:              "should return an error when the archive is not found",
+			files:                  []api_client.FileInformation{},
+			expectedError:          fmt.Errorf("installer not found for %q %q", "darwin", "arm64"),
+			expectedDownloadCalled: false,
+		},
+		{
+			testTitle:              "should return an error when the download fails",
+			files:                  []api_client.FileInformation{archive},
+			downloadError:          fmt.Errorf("some error"),
+			expectedError:          fmt.Errorf("some error"),
+			expectedDownloadCalled: true,
+		},
+		{
+			testTitle:              "should download the version",
+			files:                  []api_client.FileInformation{archive},
+			expectedDownloadCalled: true,
+			expectedMessages:       []string{"1.21.0 version is downloaded!\n"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			printer := &testutils.FakeStdout{}
+			fileHelpers := &testutils.FakeFilesHelper{}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{DownloadError: tc.downloadError}
+			log := logger.New(printer, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			ev := version.ExtendedVersion{
+				AlreadyInstalled: tc.alreadyInstalled,
+				VersionInfo:      api_client.VersionInfo{Version: "go1.21.0", IsStable: true, Files: tc.files},
+			}
+
+			err := versioner.Download(&ev, "darwin", "arm64")
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if installer.DownloadCalled != tc.expectedDownloadCalled {
+				t.Errorf("DownloadCalled should be %t, instead got %t", tc.expectedDownloadCalled, installer.DownloadCalled)
+			}
+
+			if installer.NewVersionCalled || installer.ExistingVersionCalled {
+				t.Errorf("the version should not be activated")
+			}
+
+			if !cmp.Equal(printer.GetPrintMessages(), tc.expectedMessages) {
+				t.Errorf("Wrong logs received, got=%s", cmp.Diff(tc.expectedMessages, printer.GetPrintMessages()))
+			}
+		})
+	}
+}
+
+func TestActivate(t *testing.T) {
+	testCases := []struct {
+		testTitle             string
+		alreadyInstalled      bool
+		existingVersionError  error
+		expectedError         error
+		expectedActivateCalls bool
+	}{
+		{
+			testTitle:             "should return an error when the version is not downloaded",
+			alreadyInstalled:      false,
+			expectedError:         fmt.Errorf("1.21.0 is not installed"),
+			expectedActivateCalls: false,
+		},
+		{
+			testTitle:             "should return an error when the activation fails",
+			alreadyInstalled:      true,
+			existingVersionError:  fmt.Errorf("some error"),
+			expectedError:         fmt.Errorf("some error"),
+			expectedActivateCalls: true,
+		},
+		{
+			testTitle:             "should activate the version",
+			alreadyInstalled:      true,
+			expectedActivateCalls: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testTitle, func(t *testing.T) {
+			fileHelpers := &testutils.FakeFilesHelper{}
+			clientAPI := testutils.FakeGoClientAPI{}
+			installer := &testutils.FakeInstaller{ExistingVersionError: tc.existingVersionError}
+			log := logger.New(&testutils.FakeStdout{}, nil)
+
+			versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+			ev := version.ExtendedVersion{
+				AlreadyInstalled: tc.alreadyInstalled,
+				VersionInfo:      api_client.VersionInfo{Version: "go1.21.0", IsStable: true},
+			}
+
+			err := versioner.Activate(&ev)
+
+			if tc.expectedError == nil && err != nil {
+				t.Errorf("error should be nil, instead got %q", err.Error())
+				return
+			}
+
+			if tc.expectedError != nil && (err == nil || err.Error() != tc.expectedError.Error()) {
+				t.Errorf("error should be %q, instead got %v", tc.expectedError.Error(), err)
+				return
+			}
+
+			if installer.ExistingVersionCalled != tc.expectedActivateCalls {
+				t.Errorf("ExistingVersionCalled should be %t, instead got %t", tc.expectedActivateCalls, installer.ExistingVersionCalled)
+			}
+
+			if installer.DownloadCalled || installer.NewVersionCalled {
+				t.Errorf("the version should not be downloaded")
+			}
+
+			if tc.expectedError == nil && !ev.UsedVersion {
+				t.Errorf("%s should be the used version", ev.Version)
+			}
+		})
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	names := []string{"go1.20rc1", "go1.21.0", "go1.9.2", "bad_version", "go1.20", "go1.22beta1", "go1.21rc2", "go1.20.1", "go1.10"}
+
+	evs := make([]*version.ExtendedVersion, 0, len(names))
+	for _, name := range names {
+		evs = append(evs, &version.ExtendedVersion{VersionInfo: api_client.VersionInfo{Version: name}})
+	}
+
+	version.SortVersions(evs)
+
+	res := make([]string, 0, len(evs))
+	for _, ev := range evs {
+		res = append(res, ev.Version)
+	}
+
+	expectedVersions := []string{"go1.22beta1", "go1.21.0", "go1.21rc2", "go1.20.1", "go1.20", "go1.20rc1", "go1.10", "go1.9.2", "bad_version"}
+	if !cmp.Equal(res, expectedVersions) {
+		t.Errorf("Wrong array received, got=%s", cmp.Diff(expectedVersions, res))
+	}
+}
+
+func TestGetLatestVersionReturnLatestStableVersionUnordered(t *testing.T) {
+	versions := []*version.ExtendedVersion{
+		{VersionInfo: api_client.VersionInfo{Version: "go1.20.12", IsStable: true}},
+		{VersionInfo: api_client.VersionInfo{Version: "go1.22rc1", IsStable: false}},
+		{VersionInfo: api_client.VersionInfo{Version: "go1.21.5", IsStable: true}},
+		{VersionInfo: api_client.VersionInfo{Version: "go1.21.10", IsStable: true}},
+		{VersionInfo: api_client.VersionInfo{Version: "go1.9.7", IsStable: true}},
+	}
+
+	fileHelpers := &testutils.FakeFilesHelper{}
+	clientAPI := testutils.FakeGoClientAPI{}
+	installer := &testutils.FakeInstaller{}
+	log := logger.New(&testutils.FakeStdout{}, nil)
+
+	versioner := version.New(fileHelpers, clientAPI, installer, log)
+
+	index := versioner.GetLatestVersion(versions)
+
+	if index != 3 {
+		t.Errorf("error should be 3, instead got %d", index)
+	}
+}